@@ -22,7 +22,7 @@ Custom return data:
   provider: "Etsy"
 
   attributes:
-    price: "Numeric value of the list price."
+    price: "The list price, as a decimal string (eg: 12.50)."
     currency: "The currency (in 3-letter ISO 4217 format) of the price"
 
     availability: "TODO"
@@ -36,14 +36,15 @@ func EtsyProductHandler(response *http.Response, doc *goquery.Document) (*lib.Me
 
 			if priceCurrency, exists := doc.Find("meta[property='etsymarketplace:currency_code']").First().Attr("content"); exists == true {
 
-				meta, _ := GenericHandler(response, doc)
+				if money, err := utils.ParseDecimalPrice(price, priceCurrency); err == nil {
+					meta, _ := GenericHandler(response, doc)
 
-				meta.SetType("Product")
-				meta.SetProvider("Etsy")
-				meta.SetAttr("price", price)
-				meta.SetAttr("priceCurrency", priceCurrency)
+					meta.SetType("Product")
+					meta.SetProvider("Etsy")
+					setPrice(meta, money)
 
-				return meta, true
+					return meta, true
+				}
 			}
 		}
 	}
@@ -0,0 +1,92 @@
+package contrib
+
+import (
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/deepakprakash/metascrape/lib"
+	"github.com/deepakprakash/metascrape/utils"
+)
+
+// Maximum number of (latest) entries returned for a feed.
+const maxFeedEntries = 10
+
+/*
+FeedHandler implements a handler for URLs that are RSS, Atom or JSON Feeds.
+
+Matching is done if:
+  - The response's `Content-Type` is not HTML AND
+  - The body parses as a feed.
+
+Custom return data:
+  type: "Feed"
+  provider: ""
+
+  extraData:
+    title: "Title of the feed."
+    description: "Description of the feed."
+    url: "The feed URL."
+    siteUrl: "The website the feed is for."
+    format: "rss, atom or json."
+    dateModified: "Date/Time when the feed was last updated."
+    entries: "The latest entries (title, url, summary, author, published) - newest first."
+*/
+func FeedHandler(response *http.Response, doc *goquery.Document) (*lib.Metadata, bool) {
+	if strings.Contains(strings.ToLower(response.Header.Get("Content-Type")), "html") {
+		return nil, false
+	}
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, false
+	}
+
+	feed, err := utils.ParseFeed(body, response.Request.URL)
+	if err != nil {
+		return nil, false
+	}
+
+	meta := lib.NewMetadata()
+
+	meta.SetType("Feed")
+	meta.SetProvider("")
+
+	meta.SetAttr("title", feed.Title)
+	meta.SetAttr("description", feed.Description)
+	meta.SetAttr("url", response.Request.URL.String())
+	meta.SetAttr("siteUrl", feed.SiteURL)
+	meta.SetAttr("format", feed.Format)
+
+	entries := latestFeedEntries(feed.Entries, maxFeedEntries)
+	meta.SetAttr("entries", entries)
+
+	// Feeds without an update time of their own are as recent as their latest entry.
+	dateModified := feed.Updated
+	if dateModified.IsZero() && len(entries) > 0 {
+		dateModified = entries[0].Published
+	}
+	if dateModified.IsZero() == false {
+		meta.SetAttr("dateModified", dateModified)
+	}
+
+	return meta, true
+}
+
+// latestFeedEntries returns up to `count` entries, newest first. Entries without a date keep their feed order.
+func latestFeedEntries(entries []utils.FeedEntry, count int) []utils.FeedEntry {
+	latest := append([]utils.FeedEntry{}, entries...)
+
+	sort.SliceStable(latest, func(i, j int) bool {
+		return latest[i].Published.After(latest[j].Published)
+	})
+
+	if len(latest) > count {
+		latest = latest[:count]
+	}
+
+	return latest
+}
@@ -9,16 +9,176 @@ import (
 	"github.com/deepakprakash/metascrape/utils"
 )
 
+// Maximum number of keyphrases extracted from the article text.
+const maxKeyphrases = 10
+
 func GenericHandler(response *http.Response, doc *goquery.Document) (*lib.Metadata, bool) {
 	meta := lib.NewMetadata()
 
 	meta.SetType("Webpage")
 	meta.SetProvider("")
 
-	meta.SetAttr("title", utils.ExtractTitle(doc))
+	// Title without the site name, eg: "Some Headline | The Publisher" => "Some Headline".
+	rawTitle := utils.ExtractTitle(doc)
+	title, siteName := utils.CleanTitle(rawTitle, utils.ExtractSiteName(doc), response.Request.URL.Host)
+	meta.SetAttr("title", title)
+	meta.SetAttr("rawTitle", rawTitle)
+	setDefaultAttr(meta, "siteName", siteName)
+
 	meta.SetAttr("description", utils.ExtractDescription(doc))
 	meta.SetAttr("thumbnailUrl", utils.ExtractThumbnailURL(doc))
 	meta.SetAttr("url", utils.ExtractCanonicalURL(doc, response))
 
+	// Site icons - the manifest is only fetched if enabled with MetaScraper.FetchManifest.
+	var manifest *utils.Manifest
+	if lib.ScrapeOptions(response).FetchManifest == true {
+		manifest, _ = utils.FetchManifest(doc)
+	}
+	if icons := utils.ExtractIcons(doc, manifest); len(icons) > 0 {
+		meta.SetAttr("icon", icons[0].URL)
+		meta.SetAttr("icons", icons)
+	}
+
+	// Web app name and colors - the theme color is set on its own too, for tinting previews.
+	if webApp := utils.ExtractWebApp(doc, manifest); webApp != nil {
+		meta.SetAttr("webApp", webApp)
+		setDefaultAttr(meta, "themeColor", webApp.ThemeColor)
+		setDefaultAttr(meta, "siteName", webApp.Name)
+	}
+
+	// Embeddable player (videos, audio, etc)
+	if embed := utils.ExtractEmbed(doc); embed != nil {
+		meta.SetAttr("embed", embed)
+	}
+
+	// Indexing and snippet directives, and the content rating
+	if robots := utils.ExtractRobots(doc, response.Header); robots != nil {
+		meta.SetAttr("robots", robots)
+	}
+
+	// All the links on the page - optional, as it can be large.
+	if lib.ScrapeOptions(response).InventoryLinks == true {
+		meta.SetAttr("links", utils.ExtractLinks(doc))
+	}
+
+	// Native apps the page can be opened in
+	if appLinks := utils.ExtractAppLinks(doc); appLinks != nil {
+		meta.SetAttr("appLinks", appLinks)
+	}
+
+	// Feeds advertised by the page
+	if feeds := utils.ExtractFeedLinks(doc); len(feeds) > 0 {
+		meta.SetAttr("feeds", feeds)
+	}
+
+	// Related pages - pagination, other languages, AMP and mobile versions.
+	if pagination := utils.ExtractPagination(doc); pagination != nil {
+		meta.SetAttr("pagination", pagination)
+	}
+	if alternates := utils.ExtractLanguageAlternates(doc); len(alternates) > 0 {
+		meta.SetAttr("alternateLanguages", alternates)
+	}
+	setDefaultAttr(meta, "ampUrl", utils.ExtractAMPURL(doc))
+	setDefaultAttr(meta, "mobileUrl", utils.ExtractMobileURL(doc))
+
+	// Structured data - the first item of a type we understand decides the type.
+	items := []*utils.Item{}
+
+	if jsonLD := utils.ExtractJSONLD(doc); len(jsonLD) > 0 {
+		meta.SetAttr("jsonLd", jsonLD)
+		items = append(items, jsonLD...)
+	}
+
+	if microdata := utils.ExtractMicrodata(doc); len(microdata) > 0 {
+		meta.SetAttr("microdata", microdata)
+		items = append(items, microdata...)
+	}
+
+	if rdfa := utils.ExtractRDFa(doc); len(rdfa) > 0 {
+		meta.SetAttr("rdfa", rdfa)
+		items = append(items, rdfa...)
+	}
+
+	typed := false
+	for _, item := range items {
+		if typed = applySchemaItem(meta, doc, item); typed == true {
+			break
+		}
+	}
+
+	// Geographic location
+	if location := utils.ExtractLocation(doc, items); location != nil {
+		meta.SetAttr("location", location)
+	}
+
+	// Microformats2 - used by IndieWeb blogs and personal sites.
+	if microformats := utils.ExtractMicroformats(doc); len(microformats) > 0 {
+		meta.SetAttr("microformats", microformats)
+
+		if typed == false {
+			applyMicroformats(meta, doc, microformats)
+		}
+	}
+
+	// Main article content - unless the h-entry had it.
+	contentText := ""
+	if existing, ok := meta.Attr("content"); ok == true {
+		if content, isContent := existing.(*utils.Content); isContent == true {
+			contentText = content.Text
+		}
+	} else if content := utils.ExtractContent(doc); content != nil {
+		meta.SetAttr("content", content)
+		contentText = content.Text
+	}
+
+	// Language - the article text (if any) is better for detection than the whole page.
+	language := utils.ExtractLanguage(doc, response, contentText)
+	if language != nil {
+		meta.SetAttr("language", language)
+	}
+
+	// Keywords - if the page doesn't declare any, the main keyphrases of the article are used.
+	if keywords := utils.ExtractKeywords(doc, items); len(keywords) > 0 {
+		meta.SetAttr("keywords", keywords)
+	} else if len(contentText) > 0 {
+		languageTag := ""
+		if language != nil {
+			languageTag = language.Tag
+		}
+
+		if keyphrases := utils.ExtractKeyphrases(contentText, languageTag, maxKeyphrases); len(keyphrases) > 0 {
+			meta.SetAttr("keywords", keyphrases)
+		}
+	}
+
+	// Dublin Core - used by academic, government and library sites.
+	if dc := utils.ExtractDublinCore(doc); len(dc) > 0 {
+		meta.SetAttr("dublinCore", dc)
+
+		for _, creator := range dc["creator"] {
+			addAuthors(meta, []utils.Author{{Name: creator}})
+		}
+		setDefaultAttr(meta, "identifier", dc.Get("identifier"))
+	}
+
+	// Dates - normalized to ISO 8601. The h-entry dates (if any) are kept.
+	datePublished, dateModified := utils.ExtractDates(doc, response, items)
+	if _, ok := meta.Attr("datePublished"); ok == false && datePublished.IsZero() == false {
+		meta.SetAttr("datePublished", datePublished)
+	}
+	if _, ok := meta.Attr("dateModified"); ok == false && dateModified.IsZero() == false {
+		meta.SetAttr("dateModified", dateModified)
+	}
+
+	// Thumbnail - pick the best image from the page if none is declared.
+	if thumbnail, _ := meta.Attr("thumbnailUrl"); thumbnail == "" {
+		if image := utils.ExtractBestImage(doc, lib.ScrapeOptions(response).ProbeImageSizes); image != nil {
+			meta.SetAttr("thumbnailUrl", image.URL)
+		}
+	}
+
+	// Authors from the HTML (meta tags, bylines, etc) fill in for the structured data.
+	addAuthors(meta, utils.ExtractAuthors(doc))
+
 	return meta, true
 }
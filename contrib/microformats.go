@@ -0,0 +1,209 @@
+package contrib
+
+import (
+	"html"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/deepakprakash/metascrape/lib"
+	"github.com/deepakprakash/metascrape/utils"
+)
+
+/*
+applyMicroformats maps the most relevant microformats2 item of the page onto the
+metadata. An `h-entry`, `h-event` or `h-product` describes the page itself, while
+an `h-card` is only used if there is nothing else (eg: on a personal home page).
+
+Returns false if none of the items are of a type we understand.
+*/
+func applyMicroformats(meta *lib.Metadata, doc *goquery.Document, items []*utils.Microformat) bool {
+	var card *utils.Microformat
+
+	for _, mf := range items {
+		switch {
+		case mf.Is("h-entry"):
+			applyHEntry(meta, doc, mf)
+			return true
+
+		case mf.Is("h-event"):
+			applyHEvent(meta, mf)
+			return true
+
+		case mf.Is("h-product"):
+			applyHProduct(meta, mf)
+			return true
+
+		case mf.Is("h-card") && card == nil:
+			card = mf
+		}
+	}
+
+	if card != nil {
+		applyHCard(meta, card)
+		return true
+	}
+
+	return false
+}
+
+func applyHEntry(meta *lib.Metadata, doc *goquery.Document, mf *utils.Microformat) {
+	meta.SetType("Article")
+
+	content := mf.String("content")
+
+	// Notes don't have a title, and their implied name is simply the content.
+	if name := mf.String("name"); len(name) > 0 && name != content {
+		setDefaultAttr(meta, "title", name)
+	}
+	setDefaultAttr(meta, "description", mf.String("summary"))
+	setDefaultAttr(meta, "thumbnailUrl", utils.HTTPURL(mf.String("photo")))
+
+	addAuthors(meta, mfAuthors(mf))
+	if published, err := utils.ParseDate(mf.String("published")); err == nil {
+		meta.SetAttr("datePublished", published)
+	}
+	if updated, err := utils.ParseDate(mf.String("updated")); err == nil {
+		meta.SetAttr("dateModified", updated)
+	}
+	if entryContent := mfContent(doc, mf); entryContent != nil {
+		meta.SetAttr("content", entryContent)
+	}
+	if categories := mfStrings(mf, "category"); len(categories) > 0 {
+		meta.SetAttr("categories", categories)
+	}
+}
+
+func applyHCard(meta *lib.Metadata, mf *utils.Microformat) {
+	meta.SetType("Profile")
+
+	setDefaultAttr(meta, "thumbnailUrl", utils.HTTPURL(mf.String("photo")))
+
+	meta.SetAttr("name", mf.String("name"))
+	if note := mf.String("note"); len(note) > 0 {
+		meta.SetAttr("bio", note)
+	}
+	if org := mf.String("org"); len(org) > 0 {
+		meta.SetAttr("organization", org)
+	}
+
+	// Location - unless the page declares one elsewhere.
+	if _, ok := meta.Attr("location"); ok == false {
+		if location := mfLocation(mf); location != nil {
+			meta.SetAttr("location", location)
+		}
+	}
+}
+
+func applyHEvent(meta *lib.Metadata, mf *utils.Microformat) {
+	meta.SetType("Event")
+
+	setDefaultAttr(meta, "title", mf.String("name"))
+	setDefaultAttr(meta, "description", firstNonEmpty(mf.String("summary"), mf.String("description")))
+	setDefaultAttr(meta, "thumbnailUrl", utils.HTTPURL(mf.String("photo")))
+
+	meta.SetAttr("startDate", mf.String("start"))
+	meta.SetAttr("endDate", mf.String("end"))
+	if location := mf.String("location"); len(location) > 0 {
+		meta.SetAttr("placeName", location)
+	}
+}
+
+func applyHProduct(meta *lib.Metadata, mf *utils.Microformat) {
+	meta.SetType("Product")
+
+	setDefaultAttr(meta, "title", mf.String("name"))
+	setDefaultAttr(meta, "description", mf.String("description"))
+	setDefaultAttr(meta, "thumbnailUrl", utils.HTTPURL(mf.String("photo")))
+
+	if price, err := utils.ParsePrice(mf.String("price"), ""); err == nil {
+		setPrice(meta, price)
+	}
+	if brand := mf.String("brand"); len(brand) > 0 {
+		meta.SetAttr("brand", brand)
+	}
+	if categories := mfStrings(mf, "category"); len(categories) > 0 {
+		meta.SetAttr("categories", categories)
+	}
+}
+
+// mfAuthors returns the authors of an h-entry, which can be h-cards, names or URLs.
+func mfAuthors(mf *utils.Microformat) []utils.Author {
+	authors := []utils.Author{}
+
+	for _, value := range mf.Properties["author"] {
+		switch v := value.(type) {
+		case string:
+			if authorURL := utils.HTTPURL(v); len(authorURL) > 0 {
+				authors = append(authors, utils.Author{URL: authorURL})
+			} else {
+				authors = append(authors, utils.Author{Name: v})
+			}
+		case *utils.Microformat:
+			authors = append(authors, utils.Author{
+				Name: firstNonEmpty(v.String("name"), v.Value),
+				URL:  utils.HTTPURL(v.String("url")),
+			})
+		}
+	}
+
+	return authors
+}
+
+// mfContent returns the `content` of an h-entry - sanitized, if it is embedded HTML (`e-content`).
+func mfContent(doc *goquery.Document, mf *utils.Microformat) *utils.Content {
+	for _, value := range mf.Properties["content"] {
+		switch v := value.(type) {
+		case *utils.EmbeddedContent:
+			return utils.ContentFromHTML(doc, v.HTML)
+		case string:
+			if len(v) > 0 {
+				return utils.NewContent(v, html.EscapeString(v))
+			}
+		}
+	}
+
+	return nil
+}
+
+// mfLocation returns the location of an h-card, from its (or its `p-adr h-adr` and `p-geo h-geo`) address and coordinates.
+func mfLocation(mf *utils.Microformat) *utils.Location {
+	adr, geo := mf, mf
+	if nested := mf.Microformat("adr"); nested != nil {
+		adr = nested
+	}
+	if nested := mf.Microformat("geo"); nested != nil {
+		geo = nested
+	}
+
+	address := []string{}
+	for _, name := range []string{"street-address", "locality", "region", "postal-code", "country-name"} {
+		if value := adr.String(name); len(value) > 0 {
+			address = append(address, value)
+		}
+	}
+
+	coordinates := geo.String("latitude") + "," + geo.String("longitude")
+	if len(geo.String("latitude")) == 0 {
+		// eg: `<data class="p-geo" value="37.7;-122.4">`
+		coordinates = mf.String("geo")
+	}
+
+	return utils.NewLocation(coordinates, "", strings.Join(address, ", "))
+}
+
+// mfStrings returns all the values of the property as plain text.
+func mfStrings(mf *utils.Microformat, name string) []string {
+	values := []string{}
+
+	for _, value := range mf.Properties[name] {
+		switch v := value.(type) {
+		case string:
+			values = append(values, v)
+		case *utils.Microformat:
+			values = append(values, v.Value)
+		}
+	}
+
+	return values
+}
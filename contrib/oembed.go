@@ -0,0 +1,149 @@
+package contrib
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/deepakprakash/metascrape/lib"
+	"github.com/deepakprakash/metascrape/utils"
+)
+
+// Metadata types for the oEmbed types that describe a specific kind of content.
+var oembedTypes = map[string]string{
+	"photo": "Photo",
+	"video": "Video",
+}
+
+// The oEmbed providers that have a dedicated handler, which gives more data than their oEmbed responses.
+var dedicatedOEmbedProviders = map[string]bool{
+	"YouTube":    true,
+	"SoundCloud": true,
+	"Twitter":    true,
+}
+
+/*
+OEmbedURLHandler implements a URL handler (see lib.URLHandler) for the oEmbed providers in
+the registry (utils.OEmbedProviders) - the page is not fetched for their URLs. As it runs
+before the page handlers, the providers that have a dedicated handler (eg: the
+YouTubeVideoHandler) are left to those - the OEmbedHandler still covers them if their
+handler doesn't match (eg: without an API key).
+
+Matching is done if:
+  - The URL matches a provider in the registry AND
+  - The provider has no dedicated handler (YouTube, SoundCloud and Twitter) AND
+  - A call to the oEmbed endpoint is successful.
+
+Custom return data:
+  type: "Photo" or "Video" for those oEmbed types - otherwise "Webpage".
+  provider: "The provider name."
+
+  extraData: As for the OEmbedHandler.
+*/
+func OEmbedURLHandler(pageURL *url.URL) (*lib.Metadata, bool) {
+	provider := utils.FindOEmbedProvider(pageURL.String())
+	if provider == nil || dedicatedOEmbedProviders[provider.Name] == true {
+		return nil, false
+	}
+
+	oembed, err := utils.FetchOEmbed(provider.OEmbedURL(pageURL.String()))
+	if err != nil {
+		return nil, false
+	}
+
+	meta := lib.NewMetadata()
+	meta.SetType("Webpage")
+	meta.SetAttr("url", pageURL.String())
+
+	applyOEmbed(meta, oembed, provider.Name)
+
+	return meta, true
+}
+
+/*
+OEmbedHandler implements a generic handler for oEmbed (https://oembed.com) providers.
+
+Matching is done if:
+  - The URL after redirects (or the canonical URL) matches a provider in the registry
+    (utils.OEmbedProviders) OR
+  - The page advertises an oEmbed endpoint with `<link rel="alternate" type="application/json+oembed">`
+    (or the XML variant), if enabled with MetaScraper.DiscoverOEmbed.
+  - A call to the oEmbed endpoint is successful.
+
+Custom return data:
+  type: "Photo" or "Video" for those oEmbed types - otherwise as detected by the GenericHandler.
+  provider: "The provider name."
+
+  extraData:
+    title: "Title of the content."
+    thumbnailUrl: "The thumbnail, or the image itself for photos."
+    author: "The author of the content - a list with a single author (name, url)."
+    embed: "The player for the content, with its (sanitized) HTML - for videos and rich content."
+    width: "Width of the content, in pixels."
+    height: "Height of the content, in pixels."
+*/
+func OEmbedHandler(response *http.Response, doc *goquery.Document) (*lib.Metadata, bool) {
+	providerName := ""
+	oembedURL := ""
+
+	for _, pageURL := range []string{response.Request.URL.String(), utils.ExtractCanonicalURL(doc, response)} {
+		if provider := utils.FindOEmbedProvider(pageURL); provider != nil {
+			providerName = provider.Name
+			oembedURL = provider.OEmbedURL(pageURL)
+			break
+		}
+	}
+
+	if len(oembedURL) == 0 && lib.ScrapeOptions(response).DiscoverOEmbed == true {
+		oembedURL = utils.DiscoverOEmbedURL(doc)
+	}
+
+	if len(oembedURL) == 0 {
+		return nil, false
+	}
+
+	oembed, err := utils.FetchOEmbed(oembedURL)
+	if err != nil {
+		return nil, false
+	}
+
+	meta, _ := GenericHandler(response, doc)
+	applyOEmbed(meta, oembed, providerName)
+
+	return meta, true
+}
+
+// applyOEmbed sets the type, provider and the attributes from the oEmbed response.
+func applyOEmbed(meta *lib.Metadata, oembed *utils.OEmbed, providerName string) {
+	if metaType, ok := oembedTypes[oembed.Type]; ok == true {
+		meta.SetType(metaType)
+	}
+	if len(oembed.ProviderName) > 0 {
+		providerName = oembed.ProviderName
+	}
+	meta.SetProvider(providerName)
+
+	if len(oembed.Title) > 0 {
+		meta.SetAttr("title", oembed.Title)
+	}
+
+	if oembed.Type == "photo" && len(oembed.URL) > 0 {
+		meta.SetAttr("thumbnailUrl", oembed.URL)
+	} else if len(oembed.ThumbnailURL) > 0 {
+		meta.SetAttr("thumbnailUrl", oembed.ThumbnailURL)
+	}
+
+	if len(oembed.AuthorName) > 0 {
+		meta.SetAttr("author", []utils.Author{{Name: oembed.AuthorName, URL: oembed.AuthorURL}})
+	}
+
+	if embed := utils.EmbedFromHTML(oembed.HTML); embed != nil {
+		embed.SetSize(oembed.Width, oembed.Height)
+		meta.SetAttr("embed", embed)
+	}
+	if oembed.Width > 0 && oembed.Height > 0 {
+		meta.SetAttr("width", oembed.Width)
+		meta.SetAttr("height", oembed.Height)
+	}
+}
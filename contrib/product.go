@@ -0,0 +1,64 @@
+package contrib
+
+import (
+	"net/http"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/deepakprakash/metascrape/lib"
+	"github.com/deepakprakash/metascrape/utils"
+)
+
+/*
+ProductHandler implements a generic handler for product pages of any e-commerce site.
+
+Matching is done if:
+  - A price is found in the JSON-LD/Microdata/RDFa `Product` (or `Offer`) OR
+  - A price is found in the `product:price:*` / `og:price:*` meta tags.
+
+Custom return data:
+  type: "Product"
+  provider: ""
+
+  attributes:
+    price: "The list price (the lowest price, for price ranges), as a decimal string (eg: 1234.50)."
+    maxPrice: "The highest price, for price ranges."
+    priceCurrency: "The currency (in 3-letter ISO 4217 format) of the price"
+    availability: "The schema.org ItemAvailability, eg: InStock, OutOfStock, PreOrder."
+    brand: "The brand of the product."
+    sku: "The seller's SKU for the product."
+    rating: "The aggregate rating (value, count, best)."
+*/
+func ProductHandler(response *http.Response, doc *goquery.Document) (*lib.Metadata, bool) {
+	items := append(append(utils.ExtractJSONLD(doc), utils.ExtractMicrodata(doc)...), utils.ExtractRDFa(doc)...)
+
+	product := utils.ExtractProduct(doc, items)
+	if product == nil {
+		return nil, false
+	}
+
+	meta, _ := GenericHandler(response, doc)
+
+	meta.SetType("Product")
+	setPrice(meta, product.Price)
+
+	setDefaultAttr(meta, "availability", product.Availability)
+	setDefaultAttr(meta, "brand", product.Brand)
+	setDefaultAttr(meta, "sku", product.SKU)
+
+	if product.Rating != nil {
+		meta.SetAttr("rating", product.Rating)
+	}
+
+	return meta, true
+}
+
+// setPrice sets the `price`, `priceCurrency` and (for ranges) `maxPrice` attributes.
+func setPrice(meta *lib.Metadata, price *utils.Money) {
+	meta.SetAttr("price", price.Amount)
+	meta.SetAttr("priceCurrency", price.Currency)
+
+	if len(price.MaxAmount) > 0 {
+		meta.SetAttr("maxPrice", price.MaxAmount)
+	}
+}
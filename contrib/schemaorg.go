@@ -0,0 +1,171 @@
+package contrib
+
+import (
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/deepakprakash/metascrape/lib"
+	"github.com/deepakprakash/metascrape/utils"
+)
+
+// schemaTypes maps the schema.org types we understand to the Metadata type.
+var schemaTypes = map[string]string{
+	"Product": "Product",
+
+	"Article":          "Article",
+	"NewsArticle":      "Article",
+	"BlogPosting":      "Article",
+	"ScholarlyArticle": "Article",
+	"TechArticle":      "Article",
+
+	"Recipe": "Recipe",
+
+	"VideoObject": "Video",
+	"Movie":       "Video",
+
+	"AudioObject":    "Audio",
+	"MusicRecording": "Audio",
+	"PodcastEpisode": "Audio",
+
+	"Event": "Event",
+
+	"Book": "Book",
+
+	"Person": "Profile",
+
+	"Place":         "Place",
+	"LocalBusiness": "Place",
+	"Restaurant":    "Place",
+}
+
+/*
+applySchemaItem maps a schema.org item (from Microdata, JSON-LD, etc) onto the
+metadata. Returns false if the item is not of a type we understand, in which case
+the metadata is left untouched.
+
+The generic attributes (title, description, etc) are only filled in if they are
+not already available, while the type specific attributes always take precedence.
+*/
+func applySchemaItem(meta *lib.Metadata, doc *goquery.Document, item *utils.Item) bool {
+	metaType := ""
+	for _, itemType := range item.Type {
+		if mapped, ok := schemaTypes[utils.SchemaTypeName(itemType)]; ok == true {
+			metaType = mapped
+			break
+		}
+	}
+
+	if len(metaType) == 0 {
+		return false
+	}
+
+	meta.SetType(metaType)
+
+	setDefaultAttr(meta, "title", firstNonEmpty(item.Text("name"), item.Text("headline")))
+	setDefaultAttr(meta, "description", item.Text("description"))
+	setDefaultAttr(meta, "thumbnailUrl", utils.ResolveURL(doc, schemaImage(item)))
+
+	addAuthors(meta, utils.ItemAuthors(doc, item))
+
+	if rating := utils.ItemRating(item); rating != nil {
+		meta.SetAttr("rating", rating)
+	}
+
+	if offer := item.Item("offers"); offer != nil {
+		if price, err := utils.ParseDecimalPrice(firstNonEmpty(offer.Text("price"), offer.Text("lowPrice")), offer.Text("priceCurrency")); err == nil {
+			setPrice(meta, price)
+		}
+
+		if availability := offer.Text("availability"); len(availability) > 0 {
+			meta.SetAttr("availability", utils.NormalizeAvailability(availability))
+		}
+	}
+
+	switch metaType {
+	case "Product":
+		if brand := item.Text("brand"); len(brand) > 0 {
+			meta.SetAttr("brand", brand)
+		}
+		if sku := item.Text("sku"); len(sku) > 0 {
+			meta.SetAttr("sku", sku)
+		}
+
+	case "Recipe":
+		ingredients := []string{}
+		for _, name := range []string{"recipeIngredient", "ingredients"} {
+			for _, value := range item.Properties[name] {
+				if ingredient, ok := value.(string); ok == true {
+					ingredients = append(ingredients, ingredient)
+				}
+			}
+		}
+		meta.SetAttr("ingredients", ingredients)
+		if totalTime, err := utils.NormalizeDuration(item.Text("totalTime")); err == nil {
+			meta.SetAttr("totalTime", totalTime)
+		}
+		meta.SetAttr("recipeYield", item.Text("recipeYield"))
+
+	case "Event":
+		meta.SetAttr("startDate", item.Text("startDate"))
+		meta.SetAttr("endDate", item.Text("endDate"))
+		if location := item.Text("location"); len(location) > 0 {
+			meta.SetAttr("placeName", location)
+		}
+
+	case "Video", "Audio":
+		if duration, err := utils.NormalizeDuration(item.Text("duration")); err == nil {
+			meta.SetAttr("duration", duration)
+		}
+	}
+
+	return true
+}
+
+// schemaImage returns the URL of the item's image, which can be given as a plain URL or as an ImageObject.
+func schemaImage(item *utils.Item) string {
+	if image := item.String("image"); len(image) > 0 {
+		return image
+	}
+
+	if image := item.Item("image"); image != nil {
+		return firstNonEmpty(image.String("url"), image.String("contentUrl"))
+	}
+
+	return item.String("thumbnailUrl")
+}
+
+// setDefaultAttr sets the attribute only if it is not already set to a non-empty value.
+func setDefaultAttr(meta *lib.Metadata, name string, value string) {
+	if len(value) == 0 {
+		return
+	}
+
+	if existing, ok := meta.Attr(name); ok == true {
+		if str, isString := existing.(string); isString == false || len(str) > 0 {
+			return
+		}
+	}
+
+	meta.SetAttr(name, value)
+}
+
+// addAuthors merges the authors into the `author` attribute, preferring the existing ones.
+func addAuthors(meta *lib.Metadata, authors []utils.Author) {
+	existing := []utils.Author{}
+	if value, ok := meta.Attr("author"); ok == true {
+		existing, _ = value.([]utils.Author)
+	}
+
+	if merged := utils.MergeAuthors(existing, authors); len(merged) > 0 {
+		meta.SetAttr("author", merged)
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if len(value) > 0 {
+			return value
+		}
+	}
+
+	return ""
+}
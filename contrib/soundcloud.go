@@ -6,7 +6,6 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
@@ -15,6 +14,9 @@ import (
 	"github.com/deepakprakash/metascrape/utils"
 )
 
+// Height of the (standard) SoundCloud player widget - its width adapts to the page.
+const soundCloudPlayerHeight = 166
+
 /*
 SoundcloudAudioHandler implements a basic handler for SoundCloud Audios.
 
@@ -40,8 +42,8 @@ Custom return data:
       "viewCount"
       "favouriteCount"
 
-    creator: "TODO"
-    embedDetails: "TODO"
+    author: "The SoundCloud user who uploaded the audio - a list with a single author (name, url)."
+    embed: "The embeddable player widget (url, height, autoplay, html)."
 */
 func SoundCloudAudioHandler(response *http.Response, doc *goquery.Document) (*lib.Metadata, bool) {
 
@@ -76,6 +78,7 @@ func SoundCloudAudioHandler(response *http.Response, doc *goquery.Document) (*li
 
 					type Result struct {
 						Id          int64  `json:"id"`
+						Uri         string `json:"uri"`
 						Genre       string `json:"genre"`
 						Duration    int64  `json:"duration"`
 						Title       string `json:"title"`
@@ -83,6 +86,11 @@ func SoundCloudAudioHandler(response *http.Response, doc *goquery.Document) (*li
 						CreatedAt   string `json:"created_at"`
 						ArtworkUrl  string `json:"artwork_url"`
 
+						User struct {
+							Username     string `json:"username"`
+							PermalinkUrl string `json:"permalink_url"`
+						} `json:"user"`
+
 						CommentCount   int64 `json:"comment_count"`
 						FavouriteCount int64 `json:"favoritings_count"`
 						ViewCount      int64 `json:"playback_count"`
@@ -105,15 +113,14 @@ func SoundCloudAudioHandler(response *http.Response, doc *goquery.Document) (*li
 						stats := Statistics{apiData.CommentCount, apiData.FavouriteCount, apiData.ViewCount}
 						meta.SetAttr("statistics", stats)
 
-						// Date is not in standard format - so process it.
-						dateString := strings.Replace(apiData.CreatedAt, "/", "-", -1)
-						dateString = strings.Replace(dateString, " ", "T", 1)
-						dateString = strings.Replace(dateString, " +00", "+00:", 1)
-						dateTime, _ := time.Parse(time.RFC3339Nano, dateString)
-						meta.SetAttr("datePublished", dateTime)
+						if datePublished, err := utils.ParseDate(apiData.CreatedAt); err == nil {
+							meta.SetAttr("datePublished", datePublished)
+						}
 
-						// TODO: Convert duration in seconds to ISO_8601 format.
-						meta.SetAttr("duration", apiData.Duration)
+						// Duration is in milliseconds - and missing (0) for some tracks.
+						if apiData.Duration > 0 {
+							meta.SetAttr("duration", utils.FormatDuration(time.Duration(apiData.Duration)*time.Millisecond))
+						}
 
 						meta.SetAttr("genre", apiData.Genre)
 
@@ -121,6 +128,17 @@ func SoundCloudAudioHandler(response *http.Response, doc *goquery.Document) (*li
 						meta.SetAttr("description", apiData.Description)
 						meta.SetAttr("thumbnailUrl", apiData.ArtworkUrl)
 
+						meta.SetAttr("author", []utils.Author{{
+							Name: apiData.User.Username,
+							URL:  apiData.User.PermalinkUrl,
+						}})
+
+						// The player widget is for the API URI of the track.
+						if len(apiData.Uri) > 0 {
+							widgetURL := "https://w.soundcloud.com/player/?url=" + url.QueryEscape(apiData.Uri)
+							meta.SetAttr("embed", utils.NewEmbed(widgetURL, 0, soundCloudPlayerHeight, true))
+						}
+
 						meta.SetType("Audio")
 						meta.SetProvider("SoundCloud")
 
@@ -6,7 +6,6 @@ import (
 	"os"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/ChimeraCoder/anaconda"
 	"github.com/PuerkitoBio/goquery"
@@ -61,7 +60,7 @@ Custom return data:
   extraData:
     name: "Name on Twitter."
     bio: "Twitter bio."
-    location: "Text representation of the location."
+    location: "The location given in the profile (name only)."
     handle: "Twitter username."
     dateCreated: "Date/Time when this profile was created."
 
@@ -94,10 +93,13 @@ func TwitterProfileHandler(response *http.Response, doc *goquery.Document) (*lib
 					// Populate the data from user object
 					meta.SetAttr("handle", user.ScreenName)
 					meta.SetAttr("name", user.Name)
-					meta.SetAttr("location", user.Location)
+					if location := utils.NewLocation("", user.Location, ""); location != nil {
+						meta.SetAttr("location", location)
+					}
 					meta.SetAttr("bio", user.Description)
-					dateTime, _ := time.Parse(time.RubyDate, user.CreatedAt)
-					meta.SetAttr("dateCreated", dateTime)
+					if dateCreated, err := utils.ParseDate(user.CreatedAt); err == nil {
+						meta.SetAttr("dateCreated", dateCreated)
+					}
 
 					// Populate the statistics
 					stats := make(map[string]interface{})
@@ -148,7 +150,9 @@ func extractTweetData(tweet *anaconda.Tweet) map[string]interface{} {
 
 	// Populate the data from user object
 	data["content"] = tweet.Text
-	data["datePublished"], _ = time.Parse(time.RubyDate, tweet.CreatedAt)
+	if datePublished, err := utils.ParseDate(tweet.CreatedAt); err == nil {
+		data["datePublished"] = datePublished
+	}
 
 	// Populate the entities
 	data["entities"] = extractEntities(&tweet.Entities)
@@ -200,7 +204,7 @@ Custom return data:
         },
       ]
 
-    author: "TODO"
+    author: "The tweet's author - a list with a single author (name, url, handle)."
 
 */
 func TwitterStatusHandler(response *http.Response, doc *goquery.Document) (*lib.Metadata, bool) {
@@ -223,6 +227,12 @@ func TwitterStatusHandler(response *http.Response, doc *goquery.Document) (*lib.
 							meta.SetAttr(key, val)
 						}
 
+						meta.SetAttr("author", []utils.Author{{
+							Name:   tweet.User.Name,
+							URL:    "https://twitter.com/" + tweet.User.ScreenName,
+							Handle: "@" + tweet.User.ScreenName,
+						}})
+
 						// Assign the general properties
 						// TODO: thumbnailUrl
 						meta.SetType("Status")
@@ -41,8 +41,8 @@ Custom return data:
       "viewCount"
       "favouriteCount"
 
-    creator: "TODO"
-    embedDetails: "TODO"
+    author: "The channel that published the video - a list with a single author (name, url)."
+    embed: "The embeddable player (url, width, height, aspectRatio, autoplay, html)."
 */
 func YouTubeVideoHandler(response *http.Response, doc *goquery.Document) (*lib.Metadata, bool) {
 
@@ -66,7 +66,7 @@ func YouTubeVideoHandler(response *http.Response, doc *goquery.Document) (*lib.M
 			params := apiURL.Query()
 			params.Add("key", apiKey)
 			params.Add("part", "snippet,contentDetails,statistics,player")
-			params.Add("fields", "items(id,snippet/title,snippet/publishedAt,snippet/thumbnails/medium,contentDetails/duration,statistics,player/embedHtml)")
+			params.Add("fields", "items(id,snippet/title,snippet/publishedAt,snippet/channelId,snippet/channelTitle,snippet/thumbnails/medium,contentDetails/duration,statistics,player/embedHtml)")
 			params.Add("id", videoID)
 
 			// Reinit the apiURL
@@ -82,9 +82,11 @@ func YouTubeVideoHandler(response *http.Response, doc *goquery.Document) (*lib.M
 								Duration string `json:"duration"`
 							} `json:"contentDetails"`
 							Snippet struct {
-								Title       string    `json:"title"`
-								PublishedAt time.Time `json:"publishedAt"`
-								Thumbnails  struct {
+								Title        string    `json:"title"`
+								PublishedAt  time.Time `json:"publishedAt"`
+								ChannelId    string    `json:"channelId"`
+								ChannelTitle string    `json:"channelTitle"`
+								Thumbnails   struct {
 									Medium struct {
 										Height int    `json:"height"`
 										Width  int    `json:"width"`
@@ -92,6 +94,9 @@ func YouTubeVideoHandler(response *http.Response, doc *goquery.Document) (*lib.M
 									} `json:"medium"`
 								} `json:"thumbnails"`
 							} `json:"snippet"`
+							Player struct {
+								EmbedHtml string `json:"embedHtml"`
+							} `json:"player"`
 							Statistics struct {
 								CommentCount   string `json:"commentCount"`
 								DislikeCount   string `json:"dislikeCount"`
@@ -114,13 +119,24 @@ func YouTubeVideoHandler(response *http.Response, doc *goquery.Document) (*lib.M
 							meta.SetProvider("YouTube")
 
 							// extraData := make(map[string]interface{})
-							meta.SetAttr("duration", item.ContentDetails.Duration)
+							if duration, err := utils.NormalizeDuration(item.ContentDetails.Duration); err == nil {
+								meta.SetAttr("duration", duration)
+							}
 							meta.SetAttr("statistics", item.Statistics)
 							meta.SetAttr("datePublished", item.Snippet.PublishedAt)
 
 							meta.SetAttr("title", item.Snippet.Title)
 							meta.SetAttr("thumbnailUrl", item.Snippet.Thumbnails.Medium.Url)
 
+							meta.SetAttr("author", []utils.Author{{
+								Name: item.Snippet.ChannelTitle,
+								URL:  "https://www.youtube.com/channel/" + item.Snippet.ChannelId,
+							}})
+
+							if embed := utils.EmbedFromHTML(item.Player.EmbedHtml); embed != nil {
+								meta.SetAttr("embed", embed)
+							}
+
 							return meta, true
 						}
 
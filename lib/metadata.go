@@ -20,6 +20,10 @@ func (m *Metadata) SetAttr(name string, value interface{}) {
 	m.attributes[name] = value
 }
 
+func (m *Metadata) DeleteAttr(name string) {
+	delete(m.attributes, name)
+}
+
 func (m *Metadata) Attr(name string) (interface{}, bool) {
 	value, ok := m.attributes[name]
 
@@ -1,40 +1,187 @@
 package lib
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 
 	"github.com/PuerkitoBio/goquery"
+
+	"github.com/deepakprakash/metascrape/utils"
 )
 
 type ScrapeHandler func(resp *http.Response, doc *goquery.Document) (*Metadata, bool)
 
+/*
+URLHandler scrapes a URL without the page - eg: from a provider's API. The URL handlers
+are tried before the page is fetched, so one that matches saves fetching it.
+*/
+type URLHandler func(pageURL *url.URL) (*Metadata, bool)
+
 type MetaScraper struct {
-	handlers []ScrapeHandler
+	handlers    []ScrapeHandler
+	urlHandlers []URLHandler
+	languages   []string
+	text        TextOptions
+	options     Options
+
+	respectRobots bool
 }
 
+/*
+Options are the scraper options that the handlers act on - mostly whether to make
+extra requests for a page. Handlers read them with ScrapeOptions.
+*/
+type Options struct {
+	FetchManifest   bool // Fetch the web app manifest linked by the pages, for their icons, name and colors
+	ProbeImageSizes bool // Fetch the first bytes of the candidate images to find the best thumbnail
+	DiscoverOEmbed  bool // Fetch the oEmbed responses advertised by the pages
+	InventoryLinks  bool // List all the links on the page in the `links` attribute
+}
+
+// optionsKey is the key of the Options in the context of the request handed to the handlers.
+type optionsKey struct{}
+
+// TextOptions configures the post-processing of the descriptions in the scraped metadata.
+type TextOptions struct {
+	Sanitize  bool   // Strip the tags, decode the entities, normalize to NFC and collapse the whitespace
+	MaxLength int    // Truncate at a word boundary to this many characters (0 for no limit)
+	Ellipsis  string // Appended to the truncated text (utils.DefaultEllipsis if empty)
+}
+
+// Attributes with the text that is post-processed as per the TextOptions.
+var textAttributes = []string{"description"}
+
 func (scraper *MetaScraper) Use(handler ScrapeHandler) {
 	scraper.handlers = append(scraper.handlers[:0], append([]ScrapeHandler{handler}, scraper.handlers[0:]...)...)
 }
 
+// UseURLHandler adds a handler that is tried before the page is fetched (see URLHandler).
+func (scraper *MetaScraper) UseURLHandler(handler URLHandler) {
+	scraper.urlHandlers = append([]URLHandler{handler}, scraper.urlHandlers...)
+}
+
+/*
+PreferLanguages sets the languages (BCP 47 tags, in order of preference) in which
+pages are scraped. If a page is not in a preferred language but links to a version
+in one (with `<link rel="alternate" hreflang>`), that version is scraped instead.
+*/
+func (scraper *MetaScraper) PreferLanguages(languages ...string) {
+	scraper.languages = languages
+}
+
+/*
+ProcessText sets how the descriptions - from the page or from the provider APIs - are
+cleaned up (see utils.SanitizeText) and truncated, after being scraped. By default the
+text is left as is.
+*/
+func (scraper *MetaScraper) ProcessText(options TextOptions) {
+	scraper.text = options
+}
+
+/*
+RespectRobots sets whether the publisher's snippet preferences (see utils.Robots) are
+respected. For `nosnippet` (or `max-snippet:0`) the description and the content are left
+out, and for `max-snippet` they are truncated - the content to its text. For `nosnippet`
+or `max-image-preview:none` the thumbnail and the content's images are left out. The
+`robots` attribute is always included.
+*/
+func (scraper *MetaScraper) RespectRobots(respect bool) {
+	scraper.respectRobots = respect
+}
+
+/*
+FetchManifest sets whether the web app manifest linked by a page (with `<link
+rel="manifest">`) is fetched, for its icons, name and colors. Off by default, as it makes
+a request for every such page - only the icons and colors in the page are used then.
+*/
+func (scraper *MetaScraper) FetchManifest(fetch bool) {
+	scraper.options.FetchManifest = fetch
+}
+
+/*
+ProbeImageSizes sets whether the candidate images of a page that declares no thumbnail
+are fetched (just their first bytes) to find their size, when picking the best one.
+Off by default, as it makes requests to the image hosts.
+*/
+func (scraper *MetaScraper) ProbeImageSizes(probe bool) {
+	scraper.options.ProbeImageSizes = probe
+}
+
+/*
+DiscoverOEmbed sets whether the oEmbed endpoints advertised by the pages (with
+`<link rel="alternate" type="application/json+oembed">`) are used, for the providers
+that are not in the registry. Off by default, as it makes a request for every such page.
+*/
+func (scraper *MetaScraper) DiscoverOEmbed(discover bool) {
+	scraper.options.DiscoverOEmbed = discover
+}
+
+/*
+InventoryLinks sets whether all the links on the page (see utils.ExtractLinks), with
+the summary counts, are listed in the `links` attribute. Off by default, as the list
+can be large.
+*/
+func (scraper *MetaScraper) InventoryLinks(inventory bool) {
+	scraper.options.InventoryLinks = inventory
+}
+
+// ScrapeOptions returns the Options of the scraper that is handling the response.
+func ScrapeOptions(response *http.Response) Options {
+	if response.Request == nil {
+		return Options{}
+	}
+
+	options, _ := response.Request.Context().Value(optionsKey{}).(Options)
+	return options
+}
+
 func (scraper *MetaScraper) Scrape(urlInput string) (*Metadata, error) {
 
 	if pURL, err := url.ParseRequestURI(urlInput); err == nil {
 		// This is a valid URL.
 
+		// Handlers that don't need the page are tried first, to save fetching it.
+		for _, handler := range scraper.urlHandlers {
+			if metaData, matched := handler(pURL); matched == true {
+				scraper.processText(metaData)
+				return metaData, nil
+			}
+		}
+
 		if response, err := fetchURL(pURL.String()); err == nil {
 			// Able to query URL and get data properly
 			// head, _ := getHead(response)
 
 			// Parse the response body and create tree structure required for goquery
-			if doc, err := goquery.NewDocumentFromResponse(response); err == nil {
+			if doc, body, err := parseResponse(response); err == nil {
+
+				// Switch to the version of the page in the preferred language, if there is one.
+				if alternateURL := scraper.languageAlternate(doc); len(alternateURL) > 0 {
+					if alternateResponse, err := fetchURL(alternateURL); err == nil {
+						if alternateDoc, alternateBody, err := parseResponse(alternateResponse); err == nil {
+							response, doc, body = alternateResponse, alternateDoc, alternateBody
+						}
+					}
+				}
+
+				// The handlers find the options in the request's context (see ScrapeOptions).
+				response.Request = response.Request.WithContext(context.WithValue(response.Request.Context(), optionsKey{}, scraper.options))
 
 				for _, handler := range scraper.handlers {
+					// Every handler gets a fresh copy of the body, for those that need the
+					// raw content (eg: feeds, which can't be parsed as HTML).
+					response.Body = ioutil.NopCloser(bytes.NewReader(body))
+
 					// metaData := GenericHandler(response, doc)
 					if metaData, matched := handler(response, doc); matched == true {
 						// Handler was able to process
+						scraper.processText(metaData)
+						scraper.applyRobots(metaData)
 						return metaData, nil
 					}
 				}
@@ -58,6 +205,122 @@ func (scraper *MetaScraper) Scrape(urlInput string) (*Metadata, error) {
 	}
 }
 
+/*
+parseResponse reads the whole response body and parses it as HTML. The body is
+returned along with the document so that it can be handed to the handlers.
+*/
+func parseResponse(response *http.Response) (*goquery.Document, []byte, error) {
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The final URL that was fetched after redirects, as with goquery.NewDocumentFromResponse
+	doc.Url = response.Request.URL
+
+	return doc, body, nil
+}
+
+/*
+languageAlternate returns the URL of the version of the page in the most preferred
+language, or an empty string if the page is already in that language (or there is
+no preferred language it is available in).
+*/
+func (scraper *MetaScraper) languageAlternate(doc *goquery.Document) string {
+	if len(scraper.languages) == 0 {
+		return ""
+	}
+
+	current, _ := doc.Find("html").First().Attr("lang")
+	alternates := utils.ExtractLanguageAlternates(doc)
+
+	for _, language := range scraper.languages {
+		if utils.LanguageMatches(current, language) {
+			return ""
+		}
+
+		if alternate := utils.MatchLanguageAlternate(alternates, language); alternate != nil {
+			if alternate.URL == doc.Url.String() {
+				return ""
+			}
+
+			return alternate.URL
+		}
+	}
+
+	return ""
+}
+
+// processText sanitizes and truncates the text attributes as per the TextOptions.
+func (scraper *MetaScraper) processText(metaData *Metadata) {
+	for _, name := range textAttributes {
+		value, ok := metaData.Attr(name)
+		if ok == false {
+			continue
+		}
+
+		text, ok := value.(string)
+		if ok == false {
+			continue
+		}
+
+		if scraper.text.Sanitize == true {
+			text = utils.SanitizeText(text)
+		}
+
+		metaData.SetAttr(name, utils.TruncateText(text, scraper.text.MaxLength, scraper.text.Ellipsis))
+	}
+}
+
+// applyRobots leaves out (or truncates) the description, content and images as per the robots directives.
+func (scraper *MetaScraper) applyRobots(metaData *Metadata) {
+	if scraper.respectRobots == false {
+		return
+	}
+
+	value, _ := metaData.Attr("robots")
+	robots, ok := value.(*utils.Robots)
+	if ok == false || robots == nil {
+		return
+	}
+
+	value, _ = metaData.Attr("content")
+	content, hasContent := value.(*utils.Content)
+	hasContent = hasContent && content != nil
+
+	if robots.HidesSnippet() == true {
+		metaData.SetAttr("description", "")
+		metaData.DeleteAttr("content")
+		hasContent = false
+	} else if robots.MaxSnippet != nil && *robots.MaxSnippet >= 0 {
+		if description, ok := metaData.Attr("description"); ok == true {
+			if text, isString := description.(string); isString == true {
+				metaData.SetAttr("description", utils.TruncateText(text, *robots.MaxSnippet, scraper.text.Ellipsis))
+			}
+		}
+
+		if hasContent == true {
+			content = content.Truncated(*robots.MaxSnippet, scraper.text.Ellipsis)
+			metaData.SetAttr("content", content)
+		}
+	}
+
+	if robots.HidesImage() == true {
+		metaData.SetAttr("thumbnailUrl", "")
+
+		if hasContent == true {
+			metaData.SetAttr("content", content.WithoutImages())
+		}
+	}
+}
+
 func fetchURL(url string) (*http.Response, error) {
 
 	// TODO: Implement timeouts / data restrictions
@@ -16,11 +16,18 @@ func Default() *lib.MetaScraper {
 	scraper := New()
 
 	scraper.Use(contrib.GenericHandler)
+	scraper.Use(contrib.FeedHandler)
+	scraper.Use(contrib.OEmbedHandler)
+	scraper.Use(contrib.ProductHandler)
 	scraper.Use(contrib.EtsyProductHandler)
 	scraper.Use(contrib.YouTubeVideoHandler)
 	scraper.Use(contrib.SoundCloudAudioHandler)
 	scraper.Use(contrib.TwitterProfileHandler)
 	scraper.Use(contrib.TwitterStatusHandler)
 
+	scraper.UseURLHandler(contrib.OEmbedURLHandler)
+
+	scraper.ProcessText(lib.TextOptions{Sanitize: true})
+
 	return scraper
 }
@@ -0,0 +1,120 @@
+package metascrape
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+// fakeTransport serves canned responses by host and path, and records the requested URLs.
+type fakeTransport struct {
+	bodies    map[string]string
+	requested []string
+}
+
+func (transport *fakeTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	transport.requested = append(transport.requested, request.URL.String())
+
+	status, body := http.StatusOK, transport.bodies[request.URL.Hostname()+request.URL.Path]
+	if len(body) == 0 {
+		status = http.StatusNotFound
+	}
+
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    request,
+	}, nil
+}
+
+func TestDefaultYouTubeVideo(t *testing.T) {
+	transport := &fakeTransport{bodies: map[string]string{
+		"www.youtube.com/watch": `<html><head>
+			<title>A Video - YouTube</title>
+			<link rel="canonical" href="https://www.youtube.com/watch?v=abc123">
+		</head><body></body></html>`,
+		"www.googleapis.com/youtube/v3/videos": `{"items": [{
+			"id": "abc123",
+			"contentDetails": {"duration": "PT4M13S"},
+			"snippet": {"title": "A Video", "publishedAt": "2020-03-05T10:00:00Z", "channelId": "UC1", "channelTitle": "A Channel"},
+			"statistics": {"viewCount": "1000"}
+		}]}`,
+		"www.youtube.com/oembed": `{"version": "1.0", "type": "video", "title": "A Video (oEmbed)"}`,
+	}}
+
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = transport
+	defer func() { http.DefaultTransport = defaultTransport }()
+
+	apiKey := os.Getenv("YOUTUBE_API_KEY")
+	os.Setenv("YOUTUBE_API_KEY", "key")
+	defer os.Setenv("YOUTUBE_API_KEY", apiKey)
+
+	meta, err := Default().Scrape("https://www.youtube.com/watch?v=abc123")
+	if err != nil {
+		t.Fatalf("Scrape() returned an error: %v", err)
+	}
+
+	if meta.Type != "Video" || meta.Provider != "YouTube" {
+		t.Errorf("Scrape() type, provider = %q, %q, want Video, YouTube", meta.Type, meta.Provider)
+	}
+
+	if duration, _ := meta.Attr("duration"); duration != "PT4M13S" {
+		t.Errorf("Scrape() duration = %v, want PT4M13S", duration)
+	}
+	if title, _ := meta.Attr("title"); title != "A Video" {
+		t.Errorf("Scrape() title = %v, want A Video", title)
+	}
+	if _, ok := meta.Attr("statistics"); ok == false {
+		t.Error("Scrape() has no statistics")
+	}
+
+	for _, requested := range transport.requested {
+		if strings.Contains(requested, "oembed") {
+			t.Errorf("Scrape() requested the oEmbed endpoint: %s", requested)
+		}
+	}
+}
+
+func TestDefaultFetchManifest(t *testing.T) {
+	transport := &fakeTransport{bodies: map[string]string{
+		"example.com/": `<html><head>
+			<title>Example</title>
+			<link rel="icon" href="/favicon.png" sizes="32x32">
+			<link rel="manifest" href="/manifest.json">
+		</head><body></body></html>`,
+		"example.com/manifest.json": `{"name": "Example App", "icons": [{"src": "/icon-512.png", "sizes": "512x512"}]}`,
+	}}
+
+	defaultTransport := http.DefaultTransport
+	http.DefaultTransport = transport
+	defer func() { http.DefaultTransport = defaultTransport }()
+
+	scraper := Default()
+
+	for _, fetch := range []bool{false, true} {
+		transport.requested = nil
+		scraper.FetchManifest(fetch)
+
+		meta, err := scraper.Scrape("https://example.com/")
+		if err != nil {
+			t.Fatalf("Scrape() returned an error: %v", err)
+		}
+
+		want := "https://example.com/favicon.png"
+		if fetch == true {
+			want = "https://example.com/icon-512.png"
+		}
+		if icon, _ := meta.Attr("icon"); icon != want {
+			t.Errorf("FetchManifest(%v): Scrape() icon = %v, want %s", fetch, icon, want)
+		}
+
+		if fetched := len(transport.requested) > 1; fetched != fetch {
+			t.Errorf("FetchManifest(%v): Scrape() requested %v", fetch, transport.requested)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// AppLink is a native app the page can be opened in - with the deep link into it, if any.
+type AppLink struct {
+	Platform string `json:"platform"`          // ios, iphone, ipad, android, windows_phone, windows or windows_universal
+	URL      string `json:"url,omitempty"`     // Deep link (custom scheme or universal link)
+	AppName  string `json:"appName,omitempty"` // Name of the app
+	AppID    string `json:"appId,omitempty"`   // App Store ID (iOS) or app ID (Windows)
+	Package  string `json:"package,omitempty"` // Package name (Android)
+	Class    string `json:"class,omitempty"`   // Activity class name (Android)
+}
+
+/*
+AppLinks are the native apps the page can be opened in, and whether to fall back to
+the web when none of them is installed.
+*/
+type AppLinks struct {
+	Apps        []AppLink `json:"apps,omitempty"`
+	WebURL      string    `json:"webUrl,omitempty"`
+	WebFallback bool      `json:"webFallback"`
+}
+
+// Fields of the App Links (`al:{platform}:{field}`) and Twitter app card (`twitter:app:{field}:{platform}`) meta tags.
+var appLinkFields = map[string]func(*AppLink) *string{
+	"url":          func(link *AppLink) *string { return &link.URL },
+	"app_name":     func(link *AppLink) *string { return &link.AppName },
+	"name":         func(link *AppLink) *string { return &link.AppName },
+	"app_store_id": func(link *AppLink) *string { return &link.AppID },
+	"app_id":       func(link *AppLink) *string { return &link.AppID },
+	"id":           func(link *AppLink) *string { return &link.AppID },
+	"package":      func(link *AppLink) *string { return &link.Package },
+	"class":        func(link *AppLink) *string { return &link.Class },
+}
+
+/*
+ExtractAppLinks extracts the native app deep links from App Links (http://applinks.org -
+`al:ios:url`, `al:android:package`, `al:web:url`, etc), the iOS smart app banner
+(`apple-itunes-app`), the `google-play-app` meta and the Twitter app card
+(`twitter:app:id:iphone`, etc). Returns nil if there are none.
+
+App Links tags for a platform may be repeated for several apps, as with Open Graph
+arrays - a repeated field starts the next app.
+*/
+func ExtractAppLinks(doc *goquery.Document) *AppLinks {
+	appLinks := &AppLinks{WebFallback: true}
+	current := make(map[string]int)
+
+	doc.Find("meta[property^='al:'], meta[name^='al:']").Each(func(_ int, s *goquery.Selection) {
+		key := firstNonEmptyString(attr(s, "property"), attr(s, "name"))
+		content := strings.TrimSpace(attr(s, "content"))
+
+		parts := strings.SplitN(strings.ToLower(key), ":", 3)
+		if len(parts) != 3 || len(content) == 0 {
+			return
+		}
+		platform, name := parts[1], parts[2]
+
+		if platform == "web" {
+			switch name {
+			case "url":
+				appLinks.WebURL = ResolveURL(doc, content)
+			case "should_fallback":
+				appLinks.WebFallback = content != "false" && content != "0"
+			}
+			return
+		}
+
+		field, ok := appLinkFields[name]
+		if ok == false {
+			return
+		}
+
+		index, ok := current[platform]
+		if ok == false || len(*field(&appLinks.Apps[index])) > 0 {
+			appLinks.Apps = append(appLinks.Apps, AppLink{Platform: platform})
+			index = len(appLinks.Apps) - 1
+			current[platform] = index
+		}
+		*field(&appLinks.Apps[index]) = content
+	})
+
+	// iOS smart app banner, eg: "app-id=123456, app-argument=myapp://item/1"
+	if banner := bannerParams(metaContent(doc, "meta[name='apple-itunes-app']")); len(banner["app-id"]) > 0 {
+		appLinks.add(AppLink{Platform: "ios", AppID: banner["app-id"], URL: banner["app-argument"]})
+	}
+
+	if banner := bannerParams(metaContent(doc, "meta[name='google-play-app']")); len(banner["app-id"]) > 0 {
+		appLinks.add(AppLink{Platform: "android", Package: banner["app-id"], URL: banner["app-argument"]})
+	}
+
+	// Twitter app card - the Google Play app ID is the package name.
+	for _, platform := range []string{"iphone", "ipad", "googleplay"} {
+		link := AppLink{Platform: platform}
+		for _, name := range []string{"id", "name", "url"} {
+			*appLinkFields[name](&link) = strings.TrimSpace(metaContent(doc, "meta[name='twitter:app:"+name+":"+platform+"'], meta[property='twitter:app:"+name+":"+platform+"']"))
+		}
+
+		if platform == "googleplay" {
+			link.Platform, link.Package, link.AppID = "android", link.AppID, ""
+		}
+
+		if link != (AppLink{Platform: link.Platform}) {
+			appLinks.add(link)
+		}
+	}
+
+	if len(appLinks.Apps) == 0 && len(appLinks.WebURL) == 0 {
+		return nil
+	}
+
+	return appLinks
+}
+
+// add merges the app link into the one for the same app, if there is one, or adds it.
+func (appLinks *AppLinks) add(link AppLink) {
+	for i, existing := range appLinks.Apps {
+		if sameApp(existing, link) == false {
+			continue
+		}
+
+		merged := &appLinks.Apps[i]
+		merged.URL = firstNonEmptyString(merged.URL, link.URL)
+		merged.AppName = firstNonEmptyString(merged.AppName, link.AppName)
+		merged.AppID = firstNonEmptyString(merged.AppID, link.AppID)
+		merged.Package = firstNonEmptyString(merged.Package, link.Package)
+		return
+	}
+
+	appLinks.Apps = append(appLinks.Apps, link)
+}
+
+// sameApp checks if the links are for the same app - on the same platform, with no conflicting IDs.
+func sameApp(a AppLink, b AppLink) bool {
+	if a.Platform != b.Platform {
+		return false
+	}
+
+	if len(a.AppID) > 0 && len(b.AppID) > 0 && a.AppID != b.AppID {
+		return false
+	}
+
+	if len(a.Package) > 0 && len(b.Package) > 0 && a.Package != b.Package {
+		return false
+	}
+
+	return true
+}
+
+// bannerParams parses the content of the smart app banner meta tags, eg: "app-id=123, app-argument=myapp://x".
+func bannerParams(content string) map[string]string {
+	params := make(map[string]string)
+
+	for _, param := range strings.Split(content, ",") {
+		if pair := strings.SplitN(param, "=", 2); len(pair) == 2 {
+			params[strings.ToLower(strings.TrimSpace(pair[0]))] = strings.TrimSpace(pair[1])
+		}
+	}
+
+	return params
+}
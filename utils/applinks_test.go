@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractAppLinks(t *testing.T) {
+	tests := []struct {
+		meta string
+		want *AppLinks
+	}{
+		{``, nil},
+		{
+			`<meta property="al:ios:url" content="example://item/1">
+			<meta property="al:ios:app_store_id" content="12345">
+			<meta property="al:ios:app_name" content="Example">
+			<meta property="al:android:url" content="example://item/1">
+			<meta property="al:android:package" content="com.example">
+			<meta property="al:web:url" content="/item/1">
+			<meta property="al:web:should_fallback" content="false">`,
+			&AppLinks{
+				Apps: []AppLink{
+					{Platform: "ios", URL: "example://item/1", AppID: "12345", AppName: "Example"},
+					{Platform: "android", URL: "example://item/1", Package: "com.example"},
+				},
+				WebURL: "https://example.com/item/1",
+			},
+		},
+		{
+			// A repeated field starts the next app for the platform.
+			`<meta property="al:ios:url" content="one://x"><meta property="al:ios:url" content="two://x">`,
+			&AppLinks{Apps: []AppLink{{Platform: "ios", URL: "one://x"}, {Platform: "ios", URL: "two://x"}}, WebFallback: true},
+		},
+		{
+			// The smart app banner is merged into the App Links app.
+			`<meta property="al:ios:app_store_id" content="12345">
+			<meta name="apple-itunes-app" content="app-id=12345, app-argument=example://item/1">`,
+			&AppLinks{Apps: []AppLink{{Platform: "ios", AppID: "12345", URL: "example://item/1"}}, WebFallback: true},
+		},
+		{
+			`<meta name="twitter:app:id:googleplay" content="com.example">
+			<meta name="twitter:app:name:googleplay" content="Example">
+			<meta name="twitter:app:id:iphone" content="12345">`,
+			&AppLinks{
+				Apps: []AppLink{
+					{Platform: "iphone", AppID: "12345"},
+					{Platform: "android", AppName: "Example", Package: "com.example"},
+				},
+				WebFallback: true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		doc := newTestDocument(t, "https://example.com/item/1", "<html><head>"+test.meta+"</head><body></body></html>")
+
+		if appLinks := ExtractAppLinks(doc); reflect.DeepEqual(appLinks, test.want) == false {
+			t.Errorf("ExtractAppLinks(%q) = %+v, want %+v", test.meta, appLinks, test.want)
+		}
+	}
+}
+
+func TestBannerParams(t *testing.T) {
+	params := bannerParams(" app-id=123 , App-Argument=myapp://x?a=b , invalid")
+	want := map[string]string{"app-id": "123", "app-argument": "myapp://x?a=b"}
+
+	if reflect.DeepEqual(params, want) == false {
+		t.Errorf("bannerParams() = %v, want %v", params, want)
+	}
+}
@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Author is a normalized author of a page.
+type Author struct {
+	Name   string `json:"name,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Handle string `json:"handle,omitempty"` // Social handle, eg: @jane
+}
+
+// Selectors for the common byline patterns used by blogs and news sites.
+var bylineSelectors = []string{
+	"[itemprop~='author']:not([itemscope])",
+	".byline .author",
+	".byline",
+	".author-name",
+	".article-author",
+	".post-author",
+	".entry-author",
+	"[class*='byline']",
+	"a[href*='/author/']",
+}
+
+var (
+	bylinePrefix    = regexp.MustCompile(`(?i)^\s*(written\s+)?by[:\s]+`)
+	authorSeparator = regexp.MustCompile(`(?i)\s*(?:,|&|\band\b)\s*`)
+	handlePattern   = regexp.MustCompile(`^@?([A-Za-z0-9_]{1,15})$`)
+)
+
+/*
+ExtractAuthors detects the authors of the page from the HTML - the `author` meta tag,
+`article:author`, `twitter:creator`, `rel=author` links, standalone `itemprop=author`
+elements and finally the common byline CSS patterns.
+
+The structured data authors (JSON-LD, Microdata items, etc) are available through
+ItemAuthors, and can be combined with these using MergeAuthors.
+*/
+func ExtractAuthors(doc *goquery.Document) []Author {
+	authors := []Author{}
+
+	if name, exists := doc.Find("meta[name='author']").First().Attr("content"); exists == true {
+		authors = append(authors, splitAuthorNames(name)...)
+	}
+
+	doc.Find("meta[property='article:author']").Each(func(_ int, s *goquery.Selection) {
+		content, _ := s.Attr("content")
+		authors = append(authors, authorFromValue(content))
+	})
+
+	if creator, exists := doc.Find("meta[name='twitter:creator']").First().Attr("content"); exists == true {
+		if handle := normalizeHandle(creator); len(handle) > 0 {
+			authors = append(authors, Author{Handle: handle})
+		}
+	}
+
+	doc.Find("a[rel~='author'], link[rel~='author']").Each(func(_ int, s *goquery.Selection) {
+		href, _ := s.Attr("href")
+		authors = append(authors, Author{
+			Name: cleanAuthorName(s.Text()),
+			URL:  ResolveURL(doc, href),
+		})
+	})
+
+	// Bylines are a last resort, since they are the most likely to be wrong.
+	named := false
+	for _, author := range authors {
+		named = named || len(author.Name) > 0
+	}
+
+	if named == false {
+		for _, selector := range bylineSelectors {
+			if byline := doc.Find(selector).First(); byline.Length() > 0 {
+				for _, author := range splitAuthorNames(byline.Text()) {
+					if href, exists := byline.Attr("href"); exists == true {
+						author.URL = ResolveURL(doc, href)
+					}
+					authors = append(authors, author)
+				}
+				break
+			}
+		}
+	}
+
+	return MergeAuthors(authors)
+}
+
+/*
+ItemAuthors returns the authors of a structured data item, from its `author` and
+`creator` properties. Those can either be plain names or Person/Organization items.
+*/
+func ItemAuthors(doc *goquery.Document, item *Item) []Author {
+	authors := []Author{}
+
+	for _, name := range []string{"author", "creator"} {
+		for _, value := range item.Properties[name] {
+			switch v := value.(type) {
+			case string:
+				authors = append(authors, authorFromValue(v))
+
+			case *Item:
+				author := Author{Name: v.Text("name"), URL: ResolveURL(doc, v.String("url"))}
+
+				// Social profiles are usually given as `sameAs` URLs.
+				for _, sameAs := range v.Properties["sameAs"] {
+					if profile, ok := sameAs.(string); ok == true && len(author.Handle) == 0 {
+						author.Handle = handleFromURL(profile)
+					}
+				}
+
+				authors = append(authors, author)
+			}
+		}
+	}
+
+	return MergeAuthors(authors)
+}
+
+/*
+MergeAuthors combines lists of authors, in order of preference. Authors with the same
+name (ignoring case), URL or handle are merged, filling in the missing details.
+Authors without a name, URL or handle are dropped.
+*/
+func MergeAuthors(lists ...[]Author) []Author {
+	merged := []Author{}
+
+	for _, authors := range lists {
+		for _, author := range authors {
+			author.Name = strings.TrimSpace(author.Name)
+			if len(author.Name) == 0 && len(author.URL) == 0 && len(author.Handle) == 0 {
+				continue
+			}
+
+			matched := false
+			for i := range merged {
+				if sameAuthor(merged[i], author) {
+					merged[i].Name = firstNonEmptyString(merged[i].Name, author.Name)
+					merged[i].URL = firstNonEmptyString(merged[i].URL, author.URL)
+					merged[i].Handle = firstNonEmptyString(merged[i].Handle, author.Handle)
+					matched = true
+					break
+				}
+			}
+
+			if matched == false {
+				merged = append(merged, author)
+			}
+		}
+	}
+
+	// A lone handle (eg: from `twitter:creator`) belongs to the only named author, if any.
+	if len(merged) == 2 && len(merged[1].Name) == 0 && len(merged[1].URL) == 0 && len(merged[0].Handle) == 0 {
+		merged[0].Handle = merged[1].Handle
+		merged = merged[:1]
+	}
+
+	return merged
+}
+
+func sameAuthor(a Author, b Author) bool {
+	return (len(a.Name) > 0 && strings.EqualFold(a.Name, b.Name)) ||
+		(len(a.URL) > 0 && a.URL == b.URL) ||
+		(len(a.Handle) > 0 && strings.EqualFold(a.Handle, b.Handle))
+}
+
+// authorFromValue creates an author from a value that could be a name or a profile URL.
+func authorFromValue(value string) Author {
+	value = strings.TrimSpace(value)
+
+	if profileURL := HTTPURL(value); len(profileURL) > 0 {
+		return Author{URL: profileURL, Handle: handleFromURL(profileURL)}
+	}
+
+	return Author{Name: cleanAuthorName(value)}
+}
+
+// splitAuthorNames splits a byline like "By Jane Doe and John Smith" into its authors.
+func splitAuthorNames(byline string) []Author {
+	authors := []Author{}
+
+	byline = bylinePrefix.ReplaceAllString(strings.Join(strings.Fields(byline), " "), "")
+
+	for _, name := range authorSeparator.Split(byline, -1) {
+		if name = cleanAuthorName(name); len(name) > 0 {
+			authors = append(authors, Author{Name: name})
+		}
+	}
+
+	return authors
+}
+
+func cleanAuthorName(name string) string {
+	name = bylinePrefix.ReplaceAllString(strings.Join(strings.Fields(name), " "), "")
+
+	// Bylines are short - anything longer is not a name.
+	if len(name) > 100 {
+		return ""
+	}
+
+	return name
+}
+
+func normalizeHandle(handle string) string {
+	if matches := handlePattern.FindStringSubmatch(strings.TrimSpace(handle)); matches != nil {
+		return "@" + matches[1]
+	}
+
+	return ""
+}
+
+// handleFromURL returns the handle for Twitter profile URLs.
+func handleFromURL(profileURL string) string {
+	if u, err := url.Parse(profileURL); err == nil {
+		host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+
+		if host == "twitter.com" || host == "x.com" {
+			return normalizeHandle(strings.Trim(u.Path, "/"))
+		}
+	}
+
+	return ""
+}
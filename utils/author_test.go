@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractAuthors(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []Author
+	}{
+		{
+			"meta tags",
+			`<meta name="author" content="Jane Doe and John Smith">
+			<meta property="article:author" content="https://twitter.com/janedoe">`,
+			[]Author{{Name: "Jane Doe"}, {Name: "John Smith"}, {URL: "https://twitter.com/janedoe", Handle: "@janedoe"}},
+		},
+		{
+			"lone handle",
+			`<meta name="author" content="Jane Doe"><meta name="twitter:creator" content="janedoe">`,
+			[]Author{{Name: "Jane Doe", Handle: "@janedoe"}},
+		},
+		{
+			"rel author",
+			`<a rel="author" href="/authors/jane">By Jane Doe</a>`,
+			[]Author{{Name: "Jane Doe", URL: "https://example.com/authors/jane"}},
+		},
+		{
+			"byline",
+			`<div class="byline">Written by Jane Doe, John Smith &amp; Someone Else</div>`,
+			[]Author{{Name: "Jane Doe"}, {Name: "John Smith"}, {Name: "Someone Else"}},
+		},
+		{
+			"author link",
+			`<p>Posted by <a href="/author/jane/">Jane Doe</a></p>`,
+			[]Author{{Name: "Jane Doe", URL: "https://example.com/author/jane/"}},
+		},
+		{"none", `<p>No authors here</p>`, []Author{}},
+	}
+
+	for _, test := range tests {
+		doc := newTestDocument(t, "https://example.com/posts/1", "<html><body>"+test.html+"</body></html>")
+
+		if authors := ExtractAuthors(doc); reflect.DeepEqual(authors, test.want) == false {
+			t.Errorf("%s: ExtractAuthors() = %+v, want %+v", test.name, authors, test.want)
+		}
+	}
+}
+
+func TestItemAuthors(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/posts/1", "<html><body></body></html>")
+
+	person := newItem()
+	person.addProperty("name", "Jane Doe")
+	person.addProperty("url", "/authors/jane")
+	person.addProperty("sameAs", "https://www.facebook.com/janedoe")
+	person.addProperty("sameAs", "https://twitter.com/janedoe")
+
+	item := newItem()
+	item.addProperty("author", person)
+	item.addProperty("author", "John Smith")
+	item.addProperty("creator", "jane doe")
+
+	want := []Author{{Name: "Jane Doe", URL: "https://example.com/authors/jane", Handle: "@janedoe"}, {Name: "John Smith"}}
+	if authors := ItemAuthors(doc, item); reflect.DeepEqual(authors, want) == false {
+		t.Errorf("ItemAuthors() = %+v, want %+v", authors, want)
+	}
+}
+
+func TestMergeAuthors(t *testing.T) {
+	merged := MergeAuthors(
+		[]Author{{Name: "Jane Doe", URL: "https://example.com/jane"}, {Name: " "}},
+		[]Author{{Name: "JANE DOE", Handle: "@janedoe"}, {URL: "https://example.com/jane", Name: "Jane"}, {Name: "John Smith"}},
+	)
+
+	want := []Author{{Name: "Jane Doe", URL: "https://example.com/jane", Handle: "@janedoe"}, {Name: "John Smith"}}
+	if reflect.DeepEqual(merged, want) == false {
+		t.Errorf("MergeAuthors() = %+v, want %+v", merged, want)
+	}
+}
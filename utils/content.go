@@ -0,0 +1,474 @@
+package utils
+
+import (
+	"bytes"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Content is the main (article) content of a page.
+type Content struct {
+	Text        string `json:"text"`
+	HTML        string `json:"html"`
+	WordCount   int    `json:"wordCount"`
+	ReadingTime string `json:"readingTime"` // ISO 8601 duration
+	LeadImage   string `json:"leadImage,omitempty"`
+}
+
+// Average adult reading speed in words per minute, used to estimate the reading time.
+const readingSpeed = 200
+
+// Minimum length of text for the content to be considered an article.
+const minContentLength = 140
+
+var (
+	unlikelyCandidates = regexp.MustCompile(`(?i)banner|breadcrumbs|combx|comment|community|cover-wrap|disqus|extra|foot|header|legends|menu|related|remark|replies|rss|shoutbox|sidebar|skyscraper|social|sponsor|supplemental|ad-break|agegate|pagination|pager|popup|share|subscribe|newsletter|cookie`)
+	maybeCandidates    = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	positiveWeight     = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|pagination|post|text|blog|story`)
+	negativeWeight     = regexp.MustCompile(`(?i)hidden|^hid$|\shid$|\shid\s|^hid\s|banner|combx|comment|com-|contact|foot|footer|footnote|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget`)
+)
+
+// Elements that never contain article content.
+var skippedTags = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "iframe": true, "object": true,
+	"embed": true, "form": true, "input": true, "button": true, "select": true, "textarea": true,
+	"nav": true, "aside": true, "footer": true, "header": true, "svg": true, "canvas": true,
+}
+
+// Elements (and their attributes) that are kept in the sanitized HTML. Others are unwrapped.
+var allowedTags = map[string][]string{
+	"p": nil, "br": nil, "hr": nil, "b": nil, "strong": nil, "i": nil, "em": nil, "u": nil, "s": nil,
+	"sub": nil, "sup": nil, "small": nil, "mark": nil, "q": nil, "cite": nil, "abbr": {"title"},
+	"h1": nil, "h2": nil, "h3": nil, "h4": nil, "h5": nil, "h6": nil, "blockquote": nil, "pre": nil, "code": nil,
+	"ul": nil, "ol": nil, "li": nil, "dl": nil, "dt": nil, "dd": nil,
+	"table": nil, "thead": nil, "tbody": nil, "tr": nil, "th": nil, "td": nil, "caption": nil,
+	"figure": nil, "figcaption": nil, "a": {"href", "title"}, "img": {"src", "alt", "title"},
+}
+
+// Elements after which a paragraph break is inserted in the plain text.
+var blockTags = map[string]bool{
+	"p": true, "div": true, "br": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"blockquote": true, "pre": true, "li": true, "tr": true, "figure": true, "figcaption": true, "section": true,
+	"article": true, "ul": true, "ol": true, "dl": true, "dt": true, "dd": true, "table": true,
+}
+
+/*
+ExtractContent finds the main article content of the page using a Readability
+(https://github.com/mozilla/readability) style scoring of the paragraphs, and returns
+its plain text, sanitized HTML, word count, estimated reading time and lead image.
+
+Content that is explicitly marked up (`itemprop=articleBody` or an `h-entry`'s
+`e-content`) is used as is, without scoring.
+
+The document is not modified. Returns nil if no content is found, or if the scored
+content is not long enough to be an article (eg: on home pages, search results, etc).
+*/
+func ExtractContent(doc *goquery.Document) *Content {
+	var root *html.Node
+
+	marked := doc.Find("[itemprop~='articleBody'], .h-entry .e-content").First()
+	if marked.Length() > 0 {
+		root = marked.Nodes[0]
+	} else {
+		root = findContentRoot(doc)
+	}
+
+	if root == nil {
+		return nil
+	}
+
+	var buffer bytes.Buffer
+	var text strings.Builder
+	leadImage := ""
+
+	renderContent(doc, root, &buffer, &text, &leadImage)
+
+	content := NewContent(text.String(), strings.TrimSpace(buffer.String()))
+	if len(content.Text) == 0 || (marked.Length() == 0 && len(content.Text) < minContentLength) {
+		return nil
+	}
+	content.LeadImage = leadImage
+
+	return content
+}
+
+// NewContent creates the content from its plain text and HTML, computing the word count and reading time.
+func NewContent(text string, contentHTML string) *Content {
+	content := new(Content)
+
+	content.Text = collapseParagraphs(text)
+	content.HTML = contentHTML
+	content.WordCount = len(strings.Fields(content.Text))
+
+	minutes := int(math.Ceil(float64(content.WordCount) / readingSpeed))
+	content.ReadingTime = FormatDuration(time.Duration(minutes) * time.Minute)
+
+	return content
+}
+
+/*
+Truncated returns a copy of the content with the text truncated to the length (see
+TruncateText), and the HTML replaced by the (escaped) truncated text - as the HTML
+can't be cut at an arbitrary point. The word count and reading time are kept.
+*/
+func (content *Content) Truncated(maxLength int, ellipsis string) *Content {
+	truncated := *content
+	truncated.Text = TruncateText(content.Text, maxLength, ellipsis)
+	truncated.HTML = html.EscapeString(truncated.Text)
+
+	return &truncated
+}
+
+// WithoutImages returns a copy of the content without the lead image and the images in the HTML.
+func (content *Content) WithoutImages() *Content {
+	stripped := *content
+	stripped.LeadImage = ""
+
+	root := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	nodes, err := html.ParseFragment(strings.NewReader(content.HTML), root)
+	if err != nil {
+		stripped.HTML = html.EscapeString(content.Text)
+		return &stripped
+	}
+
+	var buffer bytes.Buffer
+	for _, node := range nodes {
+		removeElements(node, atom.Img)
+		if node.Type != html.ElementNode || node.DataAtom != atom.Img {
+			html.Render(&buffer, node)
+		}
+	}
+	stripped.HTML = buffer.String()
+
+	return &stripped
+}
+
+// removeElements removes the descendant elements of the type from the node.
+func removeElements(node *html.Node, tag atom.Atom) {
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		if child.Type == html.ElementNode && child.DataAtom == tag {
+			node.RemoveChild(child)
+		} else {
+			removeElements(child, tag)
+		}
+		child = next
+	}
+}
+
+/*
+ContentFromHTML creates the content from an HTML fragment of the document (eg: an
+`h-entry`'s `e-content`), sanitized just like the extracted content. Returns nil if
+the fragment has no text.
+*/
+func ContentFromHTML(doc *goquery.Document, fragment string) *Content {
+	root := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), root)
+	if err != nil {
+		return nil
+	}
+	for _, node := range nodes {
+		root.AppendChild(node)
+	}
+
+	var buffer bytes.Buffer
+	var text strings.Builder
+	leadImage := ""
+
+	renderContent(doc, root, &buffer, &text, &leadImage)
+
+	content := NewContent(text.String(), strings.TrimSpace(buffer.String()))
+	if len(content.Text) == 0 {
+		return nil
+	}
+	content.LeadImage = leadImage
+
+	return content
+}
+
+// findContentRoot scores the paragraphs of the document and returns the best scoring container.
+func findContentRoot(doc *goquery.Document) *html.Node {
+	scores := make(map[*html.Node]float64)
+	candidates := []*html.Node{}
+
+	initialize := func(node *html.Node) {
+		if _, exists := scores[node]; exists == true {
+			return
+		}
+
+		scores[node] = tagWeight(node) + classWeight(node)
+		candidates = append(candidates, node)
+	}
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode {
+			if skippedTags[node.Data] == true || isUnlikelyCandidate(node) {
+				return
+			}
+
+			if isParagraph(node) {
+				text := strings.TrimSpace(nodeText(node))
+
+				if len(text) >= 25 && node.Parent != nil && node.Parent.Type == html.ElementNode {
+					score := 1 + float64(strings.Count(text, ",")) + math.Min(float64(len(text))/100, 3)
+
+					parent := node.Parent
+					initialize(parent)
+					scores[parent] += score
+
+					if grandParent := parent.Parent; grandParent != nil && grandParent.Type == html.ElementNode {
+						initialize(grandParent)
+						scores[grandParent] += score / 2
+					}
+				}
+
+				return
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+
+	for _, node := range doc.Nodes {
+		walk(node)
+	}
+
+	var top *html.Node
+	topScore := 0.0
+
+	for _, candidate := range candidates {
+		score := scores[candidate] * (1 - linkDensity(candidate))
+
+		if top == nil || score > topScore {
+			top, topScore = candidate, score
+		}
+	}
+
+	if top == nil {
+		return nil
+	}
+
+	// The article is sometimes split into sibling containers - so move up to the
+	// parent if it scores comparably.
+	if parent := top.Parent; parent != nil && parent.Type == html.ElementNode && parent.Data != "body" {
+		if parentScore, exists := scores[parent]; exists == true && parentScore*(1-linkDensity(parent)) >= topScore*0.75 {
+			top = parent
+		}
+	}
+
+	return top
+}
+
+// isParagraph checks if the node is a paragraph like element - ie, a `p`, `pre`, `td`
+// or a `div` used as one (without any block level children).
+func isParagraph(node *html.Node) bool {
+	switch node.Data {
+	case "p", "pre", "td":
+		return true
+	case "div":
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type == html.ElementNode && blockTags[child.Data] == true && child.Data != "br" {
+				return false
+			}
+		}
+		return true
+	}
+
+	return false
+}
+
+func isUnlikelyCandidate(node *html.Node) bool {
+	if node.Data == "body" || node.Data == "article" || node.Data == "main" {
+		return false
+	}
+
+	if role, _ := nodeAttr(node, "role"); role == "navigation" || role == "complementary" || role == "banner" {
+		return true
+	}
+
+	signature := classAndID(node)
+
+	return unlikelyCandidates.MatchString(signature) && maybeCandidates.MatchString(signature) == false
+}
+
+func tagWeight(node *html.Node) float64 {
+	switch node.Data {
+	case "article", "main":
+		return 10
+	case "div":
+		return 5
+	case "pre", "td", "blockquote":
+		return 3
+	case "address", "ol", "ul", "dl", "dd", "dt", "li", "form":
+		return -3
+	case "h1", "h2", "h3", "h4", "h5", "h6", "th":
+		return -5
+	}
+
+	return 0
+}
+
+func classWeight(node *html.Node) float64 {
+	weight := 0.0
+
+	for _, value := range []string{attrOrEmpty(node, "class"), attrOrEmpty(node, "id")} {
+		if len(value) == 0 {
+			continue
+		}
+
+		if negativeWeight.MatchString(value) {
+			weight -= 25
+		}
+		if positiveWeight.MatchString(value) {
+			weight += 25
+		}
+	}
+
+	return weight
+}
+
+// linkDensity is the proportion of the node's text that is inside links.
+func linkDensity(node *html.Node) float64 {
+	textLength := len(strings.TrimSpace(nodeText(node)))
+	if textLength == 0 {
+		return 0
+	}
+
+	linkLength := 0
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode && node.Data == "a" {
+			linkLength += len(strings.TrimSpace(nodeText(node)))
+			return
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+
+	return float64(linkLength) / float64(textLength)
+}
+
+/*
+renderContent writes the sanitized HTML and the plain text of the node's subtree.
+Only the allowed tags and attributes are kept (with their URLs resolved), while
+skipped and unlikely elements as well as link heavy blocks (eg: "related articles")
+are dropped.
+*/
+func renderContent(doc *goquery.Document, node *html.Node, buffer *bytes.Buffer, text *strings.Builder, leadImage *string) {
+	switch node.Type {
+	case html.TextNode:
+		buffer.WriteString(html.EscapeString(node.Data))
+		// Whitespace in the source is insignificant - the paragraph breaks are added for block elements.
+		text.WriteString(whitespace.ReplaceAllString(node.Data, " "))
+		return
+
+	case html.ElementNode:
+		if skippedTags[node.Data] == true || isUnlikelyCandidate(node) {
+			return
+		}
+
+		if classWeight(node) < 0 && linkDensity(node) > 0.5 {
+			return
+		}
+
+		if attrs, allowed := allowedTags[node.Data]; allowed == true {
+			keep := []html.Attribute{}
+
+			for _, name := range attrs {
+				value, exists := nodeAttr(node, name)
+				if exists == false {
+					continue
+				}
+
+				if name == "href" || name == "src" {
+					if value = ResolveURL(doc, value); len(value) == 0 {
+						continue
+					}
+				}
+
+				keep = append(keep, html.Attribute{Key: name, Val: value})
+			}
+
+			if node.Data == "img" {
+				src, _ := nodeAttr(node, "src")
+				if src = ResolveURL(doc, src); len(src) == 0 {
+					// Lazy loaded images - nothing to show.
+					return
+				}
+
+				if len(*leadImage) == 0 {
+					*leadImage = src
+				}
+			}
+
+			buffer.WriteString("<" + node.Data)
+			for _, attr := range keep {
+				buffer.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+			}
+			buffer.WriteString(">")
+
+			defer func() {
+				switch node.Data {
+				case "br", "hr", "img":
+					// Void elements
+				default:
+					buffer.WriteString("</" + node.Data + ">")
+				}
+			}()
+		}
+
+		if blockTags[node.Data] == true {
+			defer text.WriteString("\n\n")
+		}
+
+	case html.DocumentNode:
+
+	default:
+		return
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		renderContent(doc, child, buffer, text, leadImage)
+	}
+}
+
+var (
+	whitespace      = regexp.MustCompile(`\s+`)
+	paragraphBreaks = regexp.MustCompile(`\s*\n\n\s*`)
+)
+
+// collapseParagraphs collapses the whitespace in the text, preserving the paragraph breaks.
+func collapseParagraphs(text string) string {
+	paragraphs := []string{}
+
+	for _, paragraph := range paragraphBreaks.Split(text, -1) {
+		if paragraph = strings.Join(strings.Fields(paragraph), " "); len(paragraph) > 0 {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+func classAndID(node *html.Node) string {
+	return attrOrEmpty(node, "class") + " " + attrOrEmpty(node, "id")
+}
+
+func attrOrEmpty(node *html.Node, name string) string {
+	value, _ := nodeAttr(node, name)
+	return value
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractContent(t *testing.T) {
+	paragraph := "This is a paragraph of the article, with enough text in it to be scored as content, and a few commas."
+
+	doc := newTestDocument(t, "https://example.com/articles/1", `<html><body>
+		<nav><a href="/">Home</a> <a href="/about">About</a></nav>
+		<div class="sidebar"><p>`+paragraph+`</p></div>
+		<div class="article-body">
+			<h2>Heading</h2>
+			<p>`+paragraph+` <a href="/other" onclick="track()">A link</a></p>
+			<img src="/images/lead.jpg" alt="Lead" class="wide">
+			<script>var tracking = true;</script>
+			<p>`+paragraph+` <span style="color: red">More</span> text.</p>
+			<img data-src="/lazy.jpg">
+			<p><a href="javascript:void(0)">Script link</a></p>
+		</div>
+		<footer><p>`+paragraph+`</p></footer>
+	</body></html>`)
+
+	content := ExtractContent(doc)
+	if content == nil {
+		t.Fatal("ExtractContent() = nil, want the article content")
+	}
+
+	wantText := "Heading\n\n" + paragraph + " A link\n\n" + paragraph + " More text.\n\nScript link"
+	if content.Text != wantText {
+		t.Errorf("ExtractContent() text = %q, want %q", content.Text, wantText)
+	}
+
+	wantHTML := `<h2>Heading</h2><p>` + paragraph + ` <a href="https://example.com/other">A link</a></p>` +
+		`<img src="https://example.com/images/lead.jpg" alt="Lead"><p>` + paragraph + ` More text.</p><p><a>Script link</a></p>`
+	// The source whitespace between the elements is kept.
+	if html := strings.Join(strings.Fields(content.HTML), ""); html != strings.Join(strings.Fields(wantHTML), "") {
+		t.Errorf("ExtractContent() HTML = %q, want %q", content.HTML, wantHTML)
+	}
+
+	if content.LeadImage != "https://example.com/images/lead.jpg" {
+		t.Errorf("ExtractContent() lead image = %q", content.LeadImage)
+	}
+	if content.WordCount != len(strings.Fields(wantText)) {
+		t.Errorf("ExtractContent() word count = %d, want %d", content.WordCount, len(strings.Fields(wantText)))
+	}
+}
+
+func TestExtractContentMarked(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`<div itemprop="articleBody"><p>Short, but marked up.</p></div><div class="content"><p>Other</p></div>`, "Short, but marked up."},
+		{`<div class="h-entry"><p class="p-name">Title</p><div class="e-content">Short entry</div></div>`, "Short entry"},
+		{`<div class="content"><p>Too short to be an article.</p></div>`, ""},
+		{`<div itemprop="articleBody"> </div>`, ""},
+	}
+
+	for _, test := range tests {
+		doc := newTestDocument(t, "https://example.com/", "<html><body>"+test.body+"</body></html>")
+
+		text := ""
+		if content := ExtractContent(doc); content != nil {
+			text = content.Text
+		}
+
+		if text != test.want {
+			t.Errorf("ExtractContent(%q) text = %q, want %q", test.body, text, test.want)
+		}
+	}
+}
+
+func TestContentFromHTML(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/posts/1", "<html><body></body></html>")
+
+	content := ContentFromHTML(doc, `<p>Some <em>text</em> <img src="photo.jpg"></p><script>alert(1)</script><p>More</p>`)
+	if content == nil {
+		t.Fatal("ContentFromHTML() = nil, want the content")
+	}
+
+	if content.Text != "Some text\n\nMore" || content.WordCount != 3 {
+		t.Errorf("ContentFromHTML() text = %q (%d words)", content.Text, content.WordCount)
+	}
+	if want := `<p>Some <em>text</em> <img src="https://example.com/posts/photo.jpg"></p><p>More</p>`; content.HTML != want {
+		t.Errorf("ContentFromHTML() HTML = %q, want %q", content.HTML, want)
+	}
+	if content.LeadImage != "https://example.com/posts/photo.jpg" {
+		t.Errorf("ContentFromHTML() lead image = %q", content.LeadImage)
+	}
+
+	if content := ContentFromHTML(doc, `<img src="photo.jpg">`); content != nil {
+		t.Errorf("ContentFromHTML() without text = %+v, want nil", content)
+	}
+}
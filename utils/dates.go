@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Layouts tried (in order) by ParseDate.
+var dateLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05 -0700",
+	"2006/01/02 15:04:05",
+	"2006/01/02",
+	"20060102",
+	time.RFC1123,
+	time.RFC1123Z,
+	"Mon, 2 Jan 2006 15:04:05 MST",
+	"Mon, 2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 -0700",
+	time.RFC850,
+	"Monday, 02-Jan-06 15:04:05 -0700",
+	time.RFC822,
+	time.RFC822Z,
+	time.ANSIC,
+	time.UnixDate,
+	"Mon Jan _2 15:04:05 -0700 2006",
+	time.RubyDate,
+	"Monday, January 2, 2006 3:04 PM -0700",
+	"Monday, January 2, 2006 3:04 PM",
+	"Monday, January 2, 2006",
+	"January 2, 2006 3:04 PM -0700",
+	"January 2, 2006 3:04 PM",
+	"January 2, 2006 15:04 -0700",
+	"January 2, 2006 15:04",
+	"January 2, 2006",
+	"Jan 2, 2006 3:04 PM -0700",
+	"Jan 2, 2006 3:04 PM",
+	"Jan 2, 2006",
+	"2 January 2006 15:04 -0700",
+	"2 January 2006 15:04",
+	"2 January 2006",
+	"2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04",
+	"2 Jan 2006",
+	"January 2006",
+	"2006",
+}
+
+/*
+Common time zone abbreviations. time.Parse can't resolve these on its own - it
+takes an unknown abbreviation to be a zone at UTC.
+*/
+var zoneOffsets = map[string]string{
+	"UTC": "+0000", "GMT": "+0000", "UT": "+0000",
+	"EST": "-0500", "EDT": "-0400", "CST": "-0600", "CDT": "-0500",
+	"MST": "-0700", "MDT": "-0600", "PST": "-0800", "PDT": "-0700",
+	"BST": "+0100", "CET": "+0100", "CEST": "+0200", "EET": "+0200", "EEST": "+0300",
+	"IST": "+0530", "JST": "+0900", "AEST": "+1000", "AEDT": "+1100",
+}
+
+var (
+	ordinalSuffix = regexp.MustCompile(`(?i)\b(\d{1,2})(st|nd|rd|th)\b`)
+	dateLabel     = regexp.MustCompile(`(?i)^(published|posted|updated|last updated|modified|on)[:\s]+`)
+	zoneName      = regexp.MustCompile(`\(?\b([A-Z]{2,4})\b\)?`)
+	unixTimestamp = regexp.MustCompile(`^\d{9,10}$|^\d{12,13}$`)
+	urlDate       = regexp.MustCompile(`/((?:19|20)\d{2})[/-](\d{1,2})[/-](\d{1,2})(?:/|-|$)`)
+)
+
+/*
+ParseDate is a tolerant date/time parser for the formats commonly found on the web:
+ISO 8601 / RFC 3339 variants, HTTP dates (RFC 1123, etc), the formats used by
+provider APIs (RubyDate by Twitter, `2006/01/02 15:04:05 -0700` by SoundCloud), Unix
+timestamps (in seconds or milliseconds), and human readable dates like
+"March 1st, 2015 3:04 PM EST".
+
+Time zone abbreviations are resolved to their offsets for the common zones (eg: EST,
+CET) before parsing. Values without a time zone, or with an unknown abbreviation, are
+taken to be in UTC.
+*/
+func ParseDate(value string) (time.Time, error) {
+	normalized := strings.Join(strings.Fields(value), " ")
+	normalized = dateLabel.ReplaceAllString(normalized, "")
+	normalized = ordinalSuffix.ReplaceAllString(normalized, "$1")
+	normalized = strings.Replace(normalized, " at ", " ", 1)
+
+	if unixTimestamp.MatchString(normalized) {
+		timestamp, _ := strconv.ParseInt(normalized, 10, 64)
+		if len(normalized) > 10 {
+			return time.Unix(0, timestamp*int64(time.Millisecond)).UTC(), nil
+		}
+
+		return time.Unix(timestamp, 0).UTC(), nil
+	}
+
+	// Replace the zone abbreviations with their offsets, eg: "... 3:04 PM EST" => "... 3:04 PM -0500".
+	normalized = zoneName.ReplaceAllStringFunc(normalized, func(match string) string {
+		if offset, ok := zoneOffsets[zoneName.FindStringSubmatch(match)[1]]; ok == true {
+			return offset
+		}
+		return match
+	})
+
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, normalized); err == nil {
+			return parsed, nil
+		}
+	}
+
+	return time.Time{}, errors.New(fmt.Sprint("Unable to parse date: ", value))
+}
+
+/*
+ExtractDates detects the publication and modification dates of the page. The
+following sources are tried in order, and the first value that parses wins:
+
+Published:
+  - `article:published_time`
+  - `datePublished` (or `dateCreated`, `uploadDate`) of the structured data items
+  - Dublin Core `date.issued`, `issued`, `date` or `created`
+  - microformats2 `dt-published`
+  - `<time>` with `pubdate`, or the first one inside an `<article>`
+  - `meta[name=date]` and similar meta tags
+  - A date in the URL path, eg: /2015/03/01/some-article
+
+Modified:
+  - `article:modified_time`, `og:updated_time`
+  - `dateModified` of the structured data items
+  - Dublin Core `modified`
+  - microformats2 `dt-updated`
+  - The `Last-Modified` response header
+
+Zero values are returned for dates that are not found.
+*/
+func ExtractDates(doc *goquery.Document, response *http.Response, items []*Item) (time.Time, time.Time) {
+	dc := ExtractDublinCore(doc)
+
+	published := []string{metaContent(doc, "meta[property='article:published_time']")}
+	for _, item := range items {
+		published = append(published, item.String("datePublished"), item.String("dateCreated"), item.String("uploadDate"))
+	}
+	published = append(published,
+		dc.First("date.issued", "issued", "date", "created"),
+		dateTimeValue(doc.Find(".h-entry .dt-published").First()),
+		dateTimeValue(doc.Find("time[pubdate]").First()),
+		dateTimeValue(doc.Find("article time").First()),
+		metaContent(doc, "meta[name='date'], meta[name='pubdate'], meta[name='publishdate'], meta[name='publish_date'], meta[name='sailthru.date'], meta[name='parsely-pub-date'], meta[property='og:pubdate']"),
+		urlPathDate(response.Request.URL.Path),
+	)
+
+	modified := []string{
+		metaContent(doc, "meta[property='article:modified_time']"),
+		metaContent(doc, "meta[property='og:updated_time']"),
+	}
+	for _, item := range items {
+		modified = append(modified, item.String("dateModified"))
+	}
+	modified = append(modified,
+		dc.First("modified", "date.modified"),
+		dateTimeValue(doc.Find(".h-entry .dt-updated").First()),
+		response.Header.Get("Last-Modified"),
+	)
+
+	return firstDate(published), firstDate(modified)
+}
+
+func firstDate(values []string) time.Time {
+	for _, value := range values {
+		if len(strings.TrimSpace(value)) == 0 {
+			continue
+		}
+
+		if parsed, err := ParseDate(value); err == nil {
+			return parsed
+		}
+	}
+
+	return time.Time{}
+}
+
+func metaContent(doc *goquery.Document, selector string) string {
+	content, _ := doc.Find(selector).First().Attr("content")
+	return content
+}
+
+// dateTimeValue returns the `datetime` attribute of the element, or its text.
+func dateTimeValue(s *goquery.Selection) string {
+	if dateTime, exists := s.Attr("datetime"); exists == true {
+		return dateTime
+	}
+
+	return s.Text()
+}
+
+// urlPathDate returns the date in the URL path (eg: /2015/03/01/...) in YYYY-MM-DD format.
+func urlPathDate(path string) string {
+	if matches := urlDate.FindStringSubmatch(path); matches != nil {
+		month, _ := strconv.Atoi(matches[2])
+		day, _ := strconv.Atoi(matches[3])
+
+		if month >= 1 && month <= 12 && day >= 1 && day <= 31 {
+			return fmt.Sprintf("%s-%02d-%02d", matches[1], month, day)
+		}
+	}
+
+	return ""
+}
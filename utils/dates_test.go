@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string // RFC 3339, empty if the value is invalid
+	}{
+		{"2015-03-01T15:04:05Z", "2015-03-01T15:04:05Z"},
+		{"2015-03-01T15:04:05.123+05:30", "2015-03-01T15:04:05.123+05:30"},
+		{"2015-03-01T15:04:05+0100", "2015-03-01T15:04:05+01:00"},
+		{"2015-03-01", "2015-03-01T00:00:00Z"},
+		{"2015/03/01 15:04:05 +0000", "2015-03-01T15:04:05Z"},
+		{"20150301", "2015-03-01T00:00:00Z"},
+		{"Sun, 01 Mar 2015 15:04:05 GMT", "2015-03-01T15:04:05Z"},
+		{"Sun, 01 Mar 2015 15:04:05 EST", "2015-03-01T15:04:05-05:00"},
+		{"Sunday, 01-Mar-15 15:04:05 PST", "2015-03-01T15:04:05-08:00"},
+		{"Sun Mar 01 15:04:05 +0000 2015", "2015-03-01T15:04:05Z"},
+		{"March 1st, 2015 3:04 PM EST", "2015-03-01T15:04:00-05:00"},
+		{"March 1st, 2015 at 3:04 PM (CET)", "2015-03-01T15:04:00+01:00"},
+		{"Published: 1 March 2015", "2015-03-01T00:00:00Z"},
+		{"  Mar   1,\n 2015 ", "2015-03-01T00:00:00Z"},
+		{"March 2015", "2015-03-01T00:00:00Z"},
+		{"5 March 2020 10:00 EST", "2020-03-05T10:00:00-05:00"},
+		{"5 Mar 2020 10:00 +0100", "2020-03-05T10:00:00+01:00"},
+		{"2020", "2020-01-01T00:00:00Z"},
+		{"1425222245", "2015-03-01T15:04:05Z"},
+		{"1425222245000", "2015-03-01T15:04:05Z"},
+		{"1 January 1969", "1969-01-01T00:00:00Z"},
+		{"yesterday", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		parsed, err := ParseDate(test.value)
+
+		if len(test.want) == 0 {
+			if err == nil {
+				t.Errorf("ParseDate(%q) = %v, want an error", test.value, parsed)
+			}
+			continue
+		}
+
+		want, _ := time.Parse(time.RFC3339Nano, test.want)
+		if err != nil {
+			t.Errorf("ParseDate(%q) returned an error: %v", test.value, err)
+		} else if parsed.Equal(want) == false || parsed.Format("-07:00") != want.Format("-07:00") {
+			t.Errorf("ParseDate(%q) = %v, want %v", test.value, parsed.Format(time.RFC3339Nano), test.want)
+		}
+	}
+}
+
+func TestExtractDatesDublinCore(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/article", `<html><head>
+		<meta name="DC.Date.Issued" content="2015-03-01">
+		<meta name="DCTERMS.Modified" content="2015-03-02T10:00:00Z">
+	</head><body></body></html>`)
+	response := &http.Response{Request: &http.Request{URL: doc.Url}, Header: http.Header{}}
+
+	published, modified := ExtractDates(doc, response, nil)
+	if want := time.Date(2015, 3, 1, 0, 0, 0, 0, time.UTC); published.Equal(want) == false {
+		t.Errorf("ExtractDates() published = %v, want %v", published, want)
+	}
+	if want := time.Date(2015, 3, 2, 10, 0, 0, 0, time.UTC); modified.Equal(want) == false {
+		t.Errorf("ExtractDates() modified = %v, want %v", modified, want)
+	}
+}
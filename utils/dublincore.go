@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+/*
+DublinCore holds the Dublin Core metadata elements (http://dublincore.org/documents/dcmi-terms/)
+of a document, keyed by the lower-cased element name (eg: `title`, `creator`, `date`,
+`identifier`), as the case of the names varies in the wild.
+
+Refinements are kept as part of the key, eg: `<meta name="DC.Date.Issued">` => `date.issued`.
+*/
+type DublinCore map[string][]string
+
+// Get returns the first value of the given element, if any.
+func (dc DublinCore) Get(name string) string {
+	if values := dc[name]; len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// First returns the value of the first of the given elements that is present.
+func (dc DublinCore) First(names ...string) string {
+	for _, name := range names {
+		if value := dc.Get(name); len(value) > 0 {
+			return value
+		}
+	}
+
+	return ""
+}
+
+/*
+ExtractDublinCore extracts the Dublin Core metadata from `<meta name="DC.*">` and
+`<meta name="DCTERMS.*">` tags (in any case), as well as their RDFa style variants
+`<meta property="dc:*">` and `<meta property="dcterms:*">`.
+*/
+func ExtractDublinCore(doc *goquery.Document) DublinCore {
+	dc := make(DublinCore)
+
+	doc.Find("meta[name], meta[property]").Each(func(_ int, s *goquery.Selection) {
+		name, exists := s.Attr("name")
+		if exists == false {
+			name, _ = s.Attr("property")
+		}
+
+		content, exists := s.Attr("content")
+		content = strings.TrimSpace(content)
+		if exists == false || len(content) == 0 {
+			return
+		}
+
+		if element := dublinCoreElement(name); len(element) > 0 {
+			dc[element] = append(dc[element], content)
+		}
+	})
+
+	return dc
+}
+
+// dublinCoreElement returns the (lower-cased) element name for a DC meta name, or "" if it is not one.
+func dublinCoreElement(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	for _, prefix := range []string{"dcterms.", "dcterms:", "dc.", "dc:"} {
+		if strings.HasPrefix(name, prefix) {
+			return name[len(prefix):]
+		}
+	}
+
+	return ""
+}
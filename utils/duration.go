@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	isoDuration   = regexp.MustCompile(`^P(?:(\d+(?:[.,]\d+)?)Y)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)W)?(?:(\d+(?:[.,]\d+)?)D)?(?:T(?:(\d+(?:[.,]\d+)?)H)?(?:(\d+(?:[.,]\d+)?)M)?(?:(\d+(?:[.,]\d+)?)S)?)?$`)
+	clockDuration = regexp.MustCompile(`^(?:(?:(\d+):)?(\d{1,2}):)?(\d+(?:\.\d+)?)$`)
+)
+
+// Lengths of the ISO 8601 duration units, in the order of the isoDuration groups. Years and months are nominal.
+var durationUnits = []time.Duration{
+	365 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+}
+
+/*
+ParseDuration parses an ISO 8601 duration (https://en.wikipedia.org/wiki/ISO_8601#Durations),
+eg: `PT1H2M3S`, `P1DT12H`, `PT4.5S`. Years and months are taken as 365 and 30 days.
+*/
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+
+	matches := isoDuration.FindStringSubmatch(value)
+	if matches == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, errors.New(fmt.Sprint("Invalid ISO 8601 duration: ", value))
+	}
+
+	duration := 0.0
+	for i, unit := range durationUnits {
+		if amount := matches[i+1]; len(amount) > 0 {
+			number, err := strconv.ParseFloat(strings.Replace(amount, ",", ".", 1), 64)
+			if err != nil {
+				return 0, err
+			}
+
+			duration += number * float64(unit)
+		}
+	}
+
+	return time.Duration(duration), nil
+}
+
+/*
+FormatDuration formats the duration in ISO 8601, eg: `PT1H2M3S`. Durations of a
+day or more include the days (`P1DT2H`) and fractions of a second are kept up to
+milliseconds. Negative durations are formatted as zero (`PT0S`).
+*/
+func FormatDuration(duration time.Duration) string {
+	if duration <= 0 {
+		return "PT0S"
+	}
+
+	duration = duration.Round(time.Millisecond)
+
+	days := duration / (24 * time.Hour)
+	duration -= days * 24 * time.Hour
+	hours := duration / time.Hour
+	duration -= hours * time.Hour
+	minutes := duration / time.Minute
+	duration -= minutes * time.Minute
+	// Whole milliseconds, so the seconds format without float noise, eg: 1.235 rather than 1.2349999999999999.
+	seconds := float64(duration/time.Millisecond) / 1000
+
+	formatted := "P"
+	if days > 0 {
+		formatted += fmt.Sprintf("%dD", days)
+	}
+
+	if hours > 0 || minutes > 0 || seconds > 0 {
+		formatted += "T"
+
+		if hours > 0 {
+			formatted += fmt.Sprintf("%dH", hours)
+		}
+		if minutes > 0 {
+			formatted += fmt.Sprintf("%dM", minutes)
+		}
+		if seconds > 0 {
+			formatted += strconv.FormatFloat(seconds, 'f', -1, 64) + "S"
+		}
+	}
+
+	return formatted
+}
+
+/*
+NormalizeDuration normalizes a duration given as ISO 8601, in clock format (eg:
+`1:02:03`, `4:13`) or as a number of seconds, to the ISO 8601 format used in the
+metadata (see FormatDuration). Returns an error if the value is none of these.
+*/
+func NormalizeDuration(value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	if duration, err := ParseDuration(value); err == nil {
+		return FormatDuration(duration), nil
+	}
+
+	if matches := clockDuration.FindStringSubmatch(value); matches != nil {
+		hours, _ := strconv.Atoi(matches[1])
+		minutes, _ := strconv.Atoi(matches[2])
+		seconds, _ := strconv.ParseFloat(matches[3], 64)
+
+		duration := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+			time.Duration(math.Round(seconds*1000))*time.Millisecond
+
+		return FormatDuration(duration), nil
+	}
+
+	return "", errors.New(fmt.Sprint("Unable to parse duration: ", value))
+}
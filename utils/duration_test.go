@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		valid bool
+	}{
+		{"PT1H2M3S", time.Hour + 2*time.Minute + 3*time.Second, true},
+		{"pt4m13s", 4*time.Minute + 13*time.Second, true},
+		{"P1DT12H", 36 * time.Hour, true},
+		{"PT4.5S", 4500 * time.Millisecond, true},
+		{"PT0,5M", 30 * time.Second, true},
+		{"P2W", 14 * 24 * time.Hour, true},
+		{"P1Y", 365 * 24 * time.Hour, true},
+		{"P", 0, false},
+		{"PT", 0, false},
+		{"P1DT", 0, false},
+		{"1:02:03", 0, false},
+	}
+
+	for _, test := range tests {
+		duration, err := ParseDuration(test.value)
+		if test.valid == false {
+			if err == nil {
+				t.Errorf("ParseDuration(%q) = %v, want an error", test.value, duration)
+			}
+		} else if err != nil || duration != test.want {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", test.value, duration, err, test.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{0, "PT0S"},
+		{-time.Second, "PT0S"},
+		{3 * time.Second, "PT3S"},
+		{time.Hour + 2*time.Minute + 3*time.Second, "PT1H2M3S"},
+		{26 * time.Hour, "P1DT2H"},
+		{48 * time.Hour, "P2D"},
+		{1500 * time.Millisecond, "PT1.5S"},
+		{1234567 * time.Microsecond, "PT1.235S"},
+	}
+
+	for _, test := range tests {
+		if formatted := FormatDuration(test.duration); formatted != test.want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", test.duration, formatted, test.want)
+		}
+	}
+}
+
+func TestNormalizeDuration(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"PT90M", "PT1H30M"},
+		{"1:02:03", "PT1H2M3S"},
+		{"4:13", "PT4M13S"},
+		{"75", "PT1M15S"},
+		{"12.5", "PT12.5S"},
+		{"two minutes", ""},
+	}
+
+	for _, test := range tests {
+		normalized, err := NormalizeDuration(test.value)
+		if len(test.want) == 0 {
+			if err == nil {
+				t.Errorf("NormalizeDuration(%q) = %q, want an error", test.value, normalized)
+			}
+		} else if err != nil || normalized != test.want {
+			t.Errorf("NormalizeDuration(%q) = %q, %v, want %q", test.value, normalized, err, test.want)
+		}
+	}
+}
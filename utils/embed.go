@@ -0,0 +1,273 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// Embed holds the details for embedding the player of a video/audio page.
+type Embed struct {
+	URL         string  `json:"url,omitempty"` // The iframe URL
+	Width       int     `json:"width,omitempty"`
+	Height      int     `json:"height,omitempty"`
+	AspectRatio float64 `json:"aspectRatio,omitempty"` // Width / Height
+	Autoplay    bool    `json:"autoplay"`              // Whether the player is allowed to autoplay
+	HTML        string  `json:"html"`                  // Sanitized embed HTML
+}
+
+// File extensions of the media files (as opposed to HTML players) that `og:video` may point to.
+var mediaExtensions = map[string]bool{
+	".mp4": true, ".m4v": true, ".webm": true, ".ogv": true, ".mov": true, ".m3u8": true,
+	".mp3": true, ".m4a": true, ".ogg": true, ".wav": true, ".swf": true,
+}
+
+// Tags kept in the embed HTML (the players), with the attributes kept on them. They need an http(s) `src`, except for `<video>` and `<audio>` with `<source>`s.
+var allowedEmbedTags = map[string][]string{
+	"video":  {"src", "width", "height", "poster", "controls", "loop", "muted", "playsinline", "preload"},
+	"audio":  {"src", "controls", "loop", "muted", "preload"},
+	"source": {"src", "type"},
+}
+
+// Attributes kept on the iframe of the embed HTML.
+var iframeAttributes = []string{"title", "scrolling"}
+
+/*
+NewEmbed creates an Embed for the player at the (iframe) URL, with the sanitized
+`<iframe>` HTML for it. Returns nil if the URL is not an absolute http(s) URL.
+*/
+func NewEmbed(embedURL string, width int, height int, autoplay bool) *Embed {
+	if strings.HasPrefix(embedURL, "//") {
+		embedURL = "https:" + embedURL
+	}
+
+	if embedURL = HTTPURL(strings.TrimSpace(embedURL)); len(embedURL) == 0 {
+		return nil
+	}
+
+	embed := &Embed{URL: embedURL, Width: width, Height: height, AspectRatio: aspectRatio(width, height), Autoplay: autoplay}
+	embed.HTML = embed.iframe(nil)
+
+	return embed
+}
+
+/*
+ExtractEmbed extracts the embeddable player advertised by the page with the Twitter
+`twitter:player` card tags, or the Open Graph `og:video` tags (as long as it is an
+HTML player rather than the media file itself). Returns nil if there is none.
+*/
+func ExtractEmbed(doc *goquery.Document) *Embed {
+	if player := twitterMeta(doc, "twitter:player"); len(player) > 0 {
+		width := parseDimension(twitterMeta(doc, "twitter:player:width"))
+		height := parseDimension(twitterMeta(doc, "twitter:player:height"))
+
+		if embed := NewEmbed(ResolveURL(doc, player), width, height, false); embed != nil {
+			return embed
+		}
+	}
+
+	video := firstNonEmptyString(
+		metaContent(doc, "meta[property='og:video:secure_url']"),
+		metaContent(doc, "meta[property='og:video:url']"),
+		metaContent(doc, "meta[property='og:video']"),
+	)
+	videoType := strings.ToLower(metaContent(doc, "meta[property='og:video:type']"))
+
+	if len(video) > 0 && (videoType == "text/html" || (len(videoType) == 0 && isMediaFile(video) == false)) {
+		width := parseDimension(metaContent(doc, "meta[property='og:video:width']"))
+		height := parseDimension(metaContent(doc, "meta[property='og:video:height']"))
+
+		return NewEmbed(ResolveURL(doc, video), width, height, false)
+	}
+
+	return nil
+}
+
+/*
+EmbedFromHTML creates an Embed from the embed HTML of a provider (eg: an oEmbed
+response, or the YouTube API). If it has an `<iframe>`, the Embed is for the iframe's
+URL and the HTML is rebuilt from its (allowed) attributes - autoplay is allowed if the
+iframe's `allow` attribute permits it. Otherwise only the `<video>` and `<audio>` players
+(see allowedEmbedTags) are kept from the HTML.
+
+Returns nil if there is nothing left to embed.
+*/
+func EmbedFromHTML(embedHTML string) *Embed {
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+
+	nodes, err := html.ParseFragment(strings.NewReader(embedHTML), context)
+	if err != nil {
+		return nil
+	}
+
+	for _, node := range nodes {
+		if iframe := findElement(node, "iframe"); iframe != nil {
+			src, _ := nodeAttr(iframe, "src")
+			allow, _ := nodeAttr(iframe, "allow")
+			autoplay := strings.Contains(strings.ToLower(allow), "autoplay")
+
+			embed := NewEmbed(src, parseDimension(attrOrEmpty(iframe, "width")), parseDimension(attrOrEmpty(iframe, "height")), autoplay)
+			if embed != nil {
+				embed.HTML = embed.iframe(iframe)
+			}
+
+			return embed
+		}
+	}
+
+	buffer := new(bytes.Buffer)
+	for _, node := range nodes {
+		renderEmbed(node, buffer)
+	}
+
+	if sanitized := strings.TrimSpace(buffer.String()); len(sanitized) > 0 {
+		return &Embed{HTML: sanitized}
+	}
+
+	return nil
+}
+
+// SetSize sets the size of the embed (if it doesn't have one), updating its HTML.
+func (embed *Embed) SetSize(width int, height int) {
+	if embed.Width > 0 && embed.Height > 0 {
+		return
+	}
+
+	embed.Width, embed.Height = width, height
+	embed.AspectRatio = aspectRatio(width, height)
+	if len(embed.URL) > 0 {
+		embed.HTML = embed.iframe(nil)
+	}
+}
+
+// iframe renders the `<iframe>` for the embed, keeping the allowed attributes of the original iframe (if any).
+func (embed *Embed) iframe(original *html.Node) string {
+	buffer := new(bytes.Buffer)
+	buffer.WriteString(`<iframe src="` + html.EscapeString(embed.URL) + `"`)
+
+	if embed.Width > 0 {
+		buffer.WriteString(fmt.Sprintf(` width="%d"`, embed.Width))
+	}
+	if embed.Height > 0 {
+		buffer.WriteString(fmt.Sprintf(` height="%d"`, embed.Height))
+	}
+
+	if original != nil {
+		for _, name := range iframeAttributes {
+			if value, exists := nodeAttr(original, name); exists == true {
+				buffer.WriteString(" " + name + `="` + html.EscapeString(value) + `"`)
+			}
+		}
+	}
+
+	allow := "encrypted-media; fullscreen; picture-in-picture"
+	if embed.Autoplay == true {
+		allow = "autoplay; " + allow
+	}
+	buffer.WriteString(` frameborder="0" allow="` + allow + `" allowfullscreen></iframe>`)
+
+	return buffer.String()
+}
+
+/*
+renderEmbed renders the players (see allowedEmbedTags) in the node, with only their
+allowed attributes. Everything else - other tags, their attributes and the text - is
+dropped, but the players nested in them are kept.
+*/
+func renderEmbed(node *html.Node, buffer *bytes.Buffer) {
+	if node.Type != html.ElementNode {
+		return
+	}
+
+	attrs, allowed := allowedEmbedTags[node.Data]
+	if allowed == false || len(node.Namespace) > 0 {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			renderEmbed(child, buffer)
+		}
+		return
+	}
+
+	src, _ := nodeAttr(node, "src")
+	if src = HTTPURL(src); len(src) == 0 {
+		if node.Data != "video" && node.Data != "audio" {
+			return
+		}
+
+		if source := findElement(node, "source"); source == nil || len(HTTPURL(attrOrEmpty(source, "src"))) == 0 {
+			return
+		}
+	}
+
+	buffer.WriteString("<" + node.Data)
+	for _, name := range attrs {
+		value, exists := nodeAttr(node, name)
+		if exists == false {
+			continue
+		}
+
+		if name == "src" || name == "poster" {
+			if value = HTTPURL(value); len(value) == 0 {
+				continue
+			}
+		}
+
+		buffer.WriteString(" " + name + `="` + html.EscapeString(value) + `"`)
+	}
+	buffer.WriteString(">")
+
+	if node.Data == "source" {
+		// Void element
+		return
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.Data == "source" {
+			renderEmbed(child, buffer)
+		}
+	}
+
+	buffer.WriteString("</" + node.Data + ">")
+}
+
+// aspectRatio returns width / height (to 4 decimals), or 0 if either is unknown.
+func aspectRatio(width int, height int) float64 {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+
+	return math.Round(float64(width)/float64(height)*10000) / 10000
+}
+
+// findElement finds the first element with the tag name in the tree (including the node itself).
+func findElement(node *html.Node, tag string) *html.Node {
+	if node.Type == html.ElementNode && node.Data == tag {
+		return node
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if found := findElement(child, tag); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// twitterMeta returns the content of a Twitter card tag, which sites declare with either `name` or `property`.
+func twitterMeta(doc *goquery.Document, name string) string {
+	return metaContent(doc, "meta[name='"+name+"'], meta[property='"+name+"']")
+}
+
+func isMediaFile(mediaURL string) bool {
+	if queryStart := strings.IndexAny(mediaURL, "?#"); queryStart >= 0 {
+		mediaURL = mediaURL[:queryStart]
+	}
+
+	return mediaExtensions[strings.ToLower(path.Ext(mediaURL))] == true
+}
@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+func TestRenderEmbed(t *testing.T) {
+	tests := []struct {
+		html string
+		want string
+	}{
+		{
+			`<svg><a><animate attributeName="href" values="javascript:alert(1)"/><text>click</text></a></svg>`,
+			``,
+		},
+		{
+			`<div onclick="evil()"><video controls autoplay poster="javascript:x" onerror="x"><source src="https://example.com/a.mp4" type="video/mp4"><source src="javascript:x">Fallback</video></div>`,
+			`<video controls=""><source src="https://example.com/a.mp4" type="video/mp4"></video>`,
+		},
+		{
+			`<audio src="https://example.com/a.mp3" controls></audio><script>alert(1)</script>`,
+			`<audio src="https://example.com/a.mp3" controls=""></audio>`,
+		},
+		{`<video src="data:video/mp4,xx"></video>`, ``},
+		{`<video><source src="/relative.mp4"></video>`, ``},
+		{`<blockquote class="twitter-tweet"><p>Hello</p></blockquote><script src="https://platform.twitter.com/widgets.js"></script>`, ``},
+		{`<p>Text <b>only</b></p>`, ``},
+	}
+
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+
+	for _, test := range tests {
+		nodes, err := html.ParseFragment(strings.NewReader(test.html), context)
+		if err != nil {
+			t.Fatalf("Unable to parse %q: %v", test.html, err)
+		}
+
+		buffer := new(bytes.Buffer)
+		for _, node := range nodes {
+			renderEmbed(node, buffer)
+		}
+
+		if rendered := buffer.String(); rendered != test.want {
+			t.Errorf("renderEmbed(%q) = %q, want %q", test.html, rendered, test.want)
+		}
+	}
+}
+
+func TestEmbedFromHTML(t *testing.T) {
+	tests := []struct {
+		html     string
+		url      string
+		autoplay bool
+		nilEmbed bool
+	}{
+		{`<iframe src="https://www.youtube.com/embed/x" width="640" height="360" allow="autoplay; encrypted-media"></iframe>`, "https://www.youtube.com/embed/x", true, false},
+		{`<div><iframe src="//player.example.com/1" onload="evil()"></iframe></div>`, "https://player.example.com/1", false, false},
+		{`<iframe src="javascript:alert(1)"></iframe>`, "", false, true},
+		{`<script>alert(1)</script>`, "", false, true},
+	}
+
+	for _, test := range tests {
+		embed := EmbedFromHTML(test.html)
+		if test.nilEmbed == true {
+			if embed != nil {
+				t.Errorf("EmbedFromHTML(%q) = %+v, want nil", test.html, embed)
+			}
+			continue
+		}
+
+		if embed == nil {
+			t.Errorf("EmbedFromHTML(%q) = nil", test.html)
+			continue
+		}
+		if embed.URL != test.url || embed.Autoplay != test.autoplay {
+			t.Errorf("EmbedFromHTML(%q) = %q (autoplay: %v), want %q (autoplay: %v)", test.html, embed.URL, embed.Autoplay, test.url, test.autoplay)
+		}
+		if strings.Contains(embed.HTML, "onload") || strings.Contains(embed.HTML, "javascript:") {
+			t.Errorf("EmbedFromHTML(%q) HTML is not sanitized: %q", test.html, embed.HTML)
+		}
+	}
+}
+
+func TestEmbedAspectRatio(t *testing.T) {
+	embed := NewEmbed("https://example.com/embed/1", 640, 360, false)
+	if embed.AspectRatio != 1.7778 {
+		t.Errorf("NewEmbed() aspect ratio = %v, want 1.7778", embed.AspectRatio)
+	}
+
+	embed = NewEmbed("https://example.com/embed/1", 0, 0, false)
+	if embed.AspectRatio != 0 {
+		t.Errorf("NewEmbed() without a size: aspect ratio = %v, want 0", embed.AspectRatio)
+	}
+
+	embed.SetSize(400, 300)
+	if embed.AspectRatio != 1.3333 || strings.Contains(embed.HTML, `width="400" height="300"`) == false {
+		t.Errorf("SetSize() = %v, %q", embed.AspectRatio, embed.HTML)
+	}
+}
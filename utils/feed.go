@@ -0,0 +1,315 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html/charset"
+)
+
+// FeedLink is a feed advertised by a page.
+type FeedLink struct {
+	URL   string `json:"url"`
+	Type  string `json:"type"`
+	Title string `json:"title,omitempty"`
+}
+
+// Feed is a parsed RSS, Atom or JSON Feed.
+type Feed struct {
+	Format      string      `json:"format"` // rss, atom or json
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	SiteURL     string      `json:"siteUrl,omitempty"`
+	Updated     time.Time   `json:"updated"`
+	Entries     []FeedEntry `json:"entries"`
+}
+
+// FeedEntry is an item of a feed.
+type FeedEntry struct {
+	Title     string    `json:"title"`
+	URL       string    `json:"url,omitempty"`
+	Summary   string    `json:"summary,omitempty"`
+	Author    string    `json:"author,omitempty"`
+	Published time.Time `json:"published"`
+}
+
+// Feed MIME types, as used in `<link rel="alternate" type>`.
+var feedTypes = map[string]bool{
+	"application/rss+xml":   true,
+	"application/atom+xml":  true,
+	"application/feed+json": true,
+}
+
+// ExtractFeedLinks discovers the feeds advertised by the page using `<link rel="alternate">`.
+func ExtractFeedLinks(doc *goquery.Document) []FeedLink {
+	links := []FeedLink{}
+	seen := make(map[string]bool)
+
+	doc.Find("link[rel~='alternate'][type][href]").Each(func(_ int, s *goquery.Selection) {
+		linkType := strings.ToLower(strings.TrimSpace(attr(s, "type")))
+		feedURL := ResolveURL(doc, attr(s, "href"))
+
+		if feedTypes[linkType] == false || len(feedURL) == 0 || seen[feedURL] == true {
+			return
+		}
+		seen[feedURL] = true
+
+		links = append(links, FeedLink{
+			URL:   feedURL,
+			Type:  linkType,
+			Title: strings.TrimSpace(attr(s, "title")),
+		})
+	})
+
+	return links
+}
+
+/*
+ParseFeed parses the body as an RSS (0.9x, 1.0 and 2.0), Atom or JSON Feed, detecting
+the format from the content. Relative links are resolved against the feed URL.
+Returns an error if the body is not a feed.
+*/
+func ParseFeed(body []byte, feedURL *url.URL) (*Feed, error) {
+	trimmed := bytes.TrimSpace(body)
+
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return parseJSONFeed(trimmed, feedURL)
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(trimmed))
+	decoder.CharsetReader = charset.NewReaderLabel
+	// Feeds in the wild often have HTML entities (eg: `&nbsp;`) and unescaped ampersands.
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	// Find the root element
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, errors.New("Not a feed.")
+		}
+
+		if root, ok := token.(xml.StartElement); ok == true {
+			switch root.Name.Local {
+			case "rss", "RDF":
+				return parseRSS(decoder, root, feedURL)
+			case "feed":
+				return parseAtom(decoder, root, feedURL)
+			}
+
+			return nil, errors.New("Not a feed.")
+		}
+	}
+}
+
+type rssLink struct {
+	Href  string `xml:"href,attr"`
+	Value string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string    `xml:"title"`
+	Links       []rssLink `xml:"link"`
+	Description string    `xml:"description"`
+	PubDate     string    `xml:"pubDate"`
+	Date        string    `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Author      string    `xml:"author"`
+	Creator     string    `xml:"http://purl.org/dc/elements/1.1/ creator"`
+}
+
+func parseRSS(decoder *xml.Decoder, root xml.StartElement, feedURL *url.URL) (*Feed, error) {
+	var rss struct {
+		Channel struct {
+			Title         string    `xml:"title"`
+			Links         []rssLink `xml:"link"`
+			Description   string    `xml:"description"`
+			LastBuildDate string    `xml:"lastBuildDate"`
+			PubDate       string    `xml:"pubDate"`
+			Date          string    `xml:"http://purl.org/dc/elements/1.1/ date"`
+			Items         []rssItem `xml:"item"`
+		} `xml:"channel"`
+
+		// RSS 1.0 items are siblings of the channel.
+		Items []rssItem `xml:"item"`
+	}
+
+	if err := decoder.DecodeElement(&rss, &root); err != nil {
+		return nil, err
+	}
+
+	channel := rss.Channel
+	feed := &Feed{
+		Format:      "rss",
+		Title:       strings.TrimSpace(channel.Title),
+		Description: strings.TrimSpace(channel.Description),
+		SiteURL:     resolveFeedURL(feedURL, rssLinkValue(channel.Links)),
+		Updated:     firstDate([]string{channel.LastBuildDate, channel.PubDate, channel.Date}),
+		Entries:     []FeedEntry{},
+	}
+
+	for _, item := range append(channel.Items, rss.Items...) {
+		feed.Entries = append(feed.Entries, FeedEntry{
+			Title:     strings.TrimSpace(item.Title),
+			URL:       resolveFeedURL(feedURL, rssLinkValue(item.Links)),
+			Summary:   strings.TrimSpace(item.Description),
+			Author:    strings.TrimSpace(firstNonEmptyString(item.Creator, item.Author)),
+			Published: firstDate([]string{item.PubDate, item.Date}),
+		})
+	}
+
+	return feed, nil
+}
+
+// rssLinkValue returns the first `<link>` with a value - RSS 2.0 feeds often have `<atom:link href>` elements too.
+func rssLinkValue(links []rssLink) string {
+	for _, link := range links {
+		if value := strings.TrimSpace(link.Value); len(value) > 0 {
+			return value
+		}
+	}
+
+	return ""
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+func parseAtom(decoder *xml.Decoder, root xml.StartElement, feedURL *url.URL) (*Feed, error) {
+	var atom struct {
+		Title    string     `xml:"title"`
+		Subtitle string     `xml:"subtitle"`
+		Updated  string     `xml:"updated"`
+		Links    []atomLink `xml:"link"`
+		Entries  []struct {
+			Title     string     `xml:"title"`
+			Summary   string     `xml:"summary"`
+			Content   string     `xml:"content"`
+			Published string     `xml:"published"`
+			Updated   string     `xml:"updated"`
+			Links     []atomLink `xml:"link"`
+			Author    struct {
+				Name string `xml:"name"`
+			} `xml:"author"`
+		} `xml:"entry"`
+	}
+
+	if err := decoder.DecodeElement(&atom, &root); err != nil {
+		return nil, err
+	}
+
+	feed := &Feed{
+		Format:      "atom",
+		Title:       strings.TrimSpace(atom.Title),
+		Description: strings.TrimSpace(atom.Subtitle),
+		SiteURL:     resolveFeedURL(feedURL, atomAlternateLink(atom.Links)),
+		Updated:     firstDate([]string{atom.Updated}),
+		Entries:     []FeedEntry{},
+	}
+
+	for _, entry := range atom.Entries {
+		feed.Entries = append(feed.Entries, FeedEntry{
+			Title:     strings.TrimSpace(entry.Title),
+			URL:       resolveFeedURL(feedURL, atomAlternateLink(entry.Links)),
+			Summary:   strings.TrimSpace(firstNonEmptyString(entry.Summary, entry.Content)),
+			Author:    strings.TrimSpace(entry.Author.Name),
+			Published: firstDate([]string{entry.Published, entry.Updated}),
+		})
+	}
+
+	return feed, nil
+}
+
+// atomAlternateLink returns the `alternate` link (which is the default `rel`).
+func atomAlternateLink(links []atomLink) string {
+	for _, link := range links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return link.Href
+		}
+	}
+
+	return ""
+}
+
+func parseJSONFeed(body []byte, feedURL *url.URL) (*Feed, error) {
+	type jsonAuthor struct {
+		Name string `json:"name"`
+	}
+
+	var jsonFeed struct {
+		Version     string `json:"version"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		HomePageURL string `json:"home_page_url"`
+		Items       []struct {
+			Title         string       `json:"title"`
+			URL           string       `json:"url"`
+			Summary       string       `json:"summary"`
+			ContentText   string       `json:"content_text"`
+			DatePublished string       `json:"date_published"`
+			DateModified  string       `json:"date_modified"`
+			Author        jsonAuthor   `json:"author"`
+			Authors       []jsonAuthor `json:"authors"`
+		} `json:"items"`
+	}
+
+	if err := json.Unmarshal(body, &jsonFeed); err != nil || strings.HasPrefix(jsonFeed.Version, "https://jsonfeed.org/version/") == false {
+		return nil, errors.New("Not a feed.")
+	}
+
+	feed := &Feed{
+		Format:      "json",
+		Title:       strings.TrimSpace(jsonFeed.Title),
+		Description: strings.TrimSpace(jsonFeed.Description),
+		SiteURL:     resolveFeedURL(feedURL, jsonFeed.HomePageURL),
+		Entries:     []FeedEntry{},
+	}
+
+	for _, item := range jsonFeed.Items {
+		author := item.Author.Name
+		if len(item.Authors) > 0 {
+			author = item.Authors[0].Name
+		}
+
+		entry := FeedEntry{
+			Title:     strings.TrimSpace(item.Title),
+			URL:       resolveFeedURL(feedURL, item.URL),
+			Summary:   strings.TrimSpace(firstNonEmptyString(item.Summary, item.ContentText)),
+			Author:    strings.TrimSpace(author),
+			Published: firstDate([]string{item.DatePublished}),
+		}
+		feed.Entries = append(feed.Entries, entry)
+
+		// JSON Feeds have no update time of their own.
+		for _, updated := range []time.Time{entry.Published, firstDate([]string{item.DateModified})} {
+			if updated.After(feed.Updated) {
+				feed.Updated = updated
+			}
+		}
+	}
+
+	return feed, nil
+}
+
+func resolveFeedURL(feedURL *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if len(ref) == 0 {
+		return ""
+	}
+
+	if feedURL != nil {
+		if resolved, err := feedURL.Parse(ref); err == nil {
+			return HTTPURL(resolved.String())
+		}
+	}
+
+	return HTTPURL(ref)
+}
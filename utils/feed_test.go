@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseFeed(t *testing.T) {
+	feedURL, _ := url.Parse("https://example.com/feed")
+	published := time.Date(2015, 3, 1, 15, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		body string
+		want *Feed
+	}{
+		{
+			"RSS with HTML entities",
+			`<?xml version="1.0"?>
+			<rss version="2.0"><channel>
+				<title>Tom &amp; Jerry&nbsp;News &copy; 2015</title>
+				<link>https://example.com/?a=1&b=2</link>
+				<description>News</description>
+				<lastBuildDate>Sun, 01 Mar 2015 15:04:05 GMT</lastBuildDate>
+				<item>
+					<title>First &mdash; post</title>
+					<link>/posts/1</link>
+					<pubDate>Sun, 01 Mar 2015 15:04:05 GMT</pubDate>
+				</item>
+			</channel></rss>`,
+			&Feed{
+				Format: "rss", Title: "Tom & Jerry News © 2015", Description: "News", SiteURL: "https://example.com/?a=1&b=2",
+				Updated: published,
+				Entries: []FeedEntry{{Title: "First — post", URL: "https://example.com/posts/1", Published: published}},
+			},
+		},
+		{
+			"Atom",
+			`<feed xmlns="http://www.w3.org/2005/Atom">
+				<title>Example</title>
+				<link rel="alternate" href="https://example.com/"/>
+				<link rel="self" href="https://example.com/feed"/>
+				<updated>2015-03-01T15:04:05Z</updated>
+				<entry>
+					<title>Post</title>
+					<link href="https://example.com/posts/1"/>
+					<published>2015-03-01T15:04:05Z</published>
+					<author><name>Someone</name></author>
+				</entry>
+			</feed>`,
+			&Feed{
+				Format: "atom", Title: "Example", SiteURL: "https://example.com/", Updated: published,
+				Entries: []FeedEntry{{Title: "Post", URL: "https://example.com/posts/1", Author: "Someone", Published: published}},
+			},
+		},
+		{
+			"JSON Feed",
+			`{"version": "https://jsonfeed.org/version/1.1", "title": "Example", "home_page_url": "/",
+				"items": [{"title": "Post", "url": "/posts/1", "content_text": "Text", "date_published": "2015-03-01T15:04:05Z", "authors": [{"name": "Someone"}]}]}`,
+			&Feed{
+				Format: "json", Title: "Example", SiteURL: "https://example.com/", Updated: published,
+				Entries: []FeedEntry{{Title: "Post", URL: "https://example.com/posts/1", Summary: "Text", Author: "Someone", Published: published}},
+			},
+		},
+		{"JSON that is not a feed", `{"title": "Example"}`, nil},
+		{"HTML", `<!DOCTYPE html><html><body>Not a feed</body></html>`, nil},
+	}
+
+	for _, test := range tests {
+		feed, err := ParseFeed([]byte(test.body), feedURL)
+
+		if test.want == nil {
+			if err == nil {
+				t.Errorf("%s: ParseFeed() = %+v, want an error", test.name, feed)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: ParseFeed() returned an error: %v", test.name, err)
+			continue
+		}
+
+		// Compare the dates as instants, regardless of the zone they were parsed in.
+		feed.Updated = feed.Updated.UTC()
+		for i := range feed.Entries {
+			feed.Entries[i].Published = feed.Entries[i].Published.UTC()
+		}
+
+		if reflect.DeepEqual(feed, test.want) == false {
+			t.Errorf("%s: ParseFeed() = %+v, want %+v", test.name, feed, test.want)
+		}
+	}
+}
+
+func TestExtractFeedLinks(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/blog/", `<html><head>
+		<link rel="alternate" type="application/rss+xml" title="RSS" href="/feed.xml">
+		<link rel="alternate" type="application/atom+xml" href="atom.xml">
+		<link rel="alternate" type="application/feed+json" href="/feed.json">
+		<link rel="alternate" type="application/json" href="/wp-json/wp/v2/posts/1">
+		<link rel="alternate" type="application/rss+xml" href="/feed.xml">
+		<link rel="alternate" hreflang="de" href="/de/">
+	</head><body></body></html>`)
+
+	want := []FeedLink{
+		{URL: "https://example.com/feed.xml", Type: "application/rss+xml", Title: "RSS"},
+		{URL: "https://example.com/blog/atom.xml", Type: "application/atom+xml"},
+		{URL: "https://example.com/feed.json", Type: "application/feed+json"},
+	}
+
+	if links := ExtractFeedLinks(doc); reflect.DeepEqual(links, want) == false {
+		t.Errorf("ExtractFeedLinks() = %+v, want %+v", links, want)
+	}
+}
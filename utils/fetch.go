@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Timeout for the requests made for a page's resources (manifests, oEmbed responses, image probes).
+const fetchTimeout = 10 * time.Second
+
+// Maximum size of the resources that are read in full (manifests, oEmbed responses).
+const maxFetchSize = 1 << 20
+
+// httpClient is used for the requests made for a page's resources, so that a slow host can't hang the scraping.
+var httpClient = &http.Client{Timeout: fetchTimeout}
+
+/*
+fetch GETs the resource and returns (at most maxFetchSize bytes of) its body.
+Returns an error if the request fails or the response is not `200 OK`.
+*/
+func fetch(resourceURL string) ([]byte, error) {
+	response, err := httpClient.Get(resourceURL)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.New(fmt.Sprint("Unable to fetch ", resourceURL, ": ", response.Status))
+	}
+
+	return ioutil.ReadAll(io.LimitReader(response.Body, maxFetchSize))
+}
@@ -0,0 +1,246 @@
+package utils
+
+import (
+	"encoding/json"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Location is a geographic location, normalized from the various ways pages declare one.
+type Location struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lng"`
+	Name      string  `json:"name,omitempty"`
+	Address   string  `json:"address,omitempty"`
+
+	hasCoordinates bool
+}
+
+var (
+	coordinatePair  = regexp.MustCompile(`^\s*(-?\d{1,3}(?:\.\d+)?)\s*[,;~ ]\s*(-?\d{1,3}(?:\.\d+)?)\s*$`)
+	mapAtCoordinate = regexp.MustCompile(`@(-?\d{1,3}\.\d+),(-?\d{1,3}\.\d+)`)
+	osmMapFragment  = regexp.MustCompile(`map=\d+/(-?\d{1,3}\.\d+)/(-?\d{1,3}\.\d+)`)
+)
+
+// Query parameters of the map links that hold coordinates (as "lat,lng").
+var mapCoordinateParams = []string{"ll", "q", "query", "center", "daddr", "sll", "cp"}
+
+// schema.org Place types (the common ones).
+var placeTypes = []string{
+	"Place", "LocalBusiness", "Restaurant", "FoodEstablishment", "Hotel", "LodgingBusiness", "Store",
+	"TouristAttraction", "Landmark", "LandmarksOrHistoricalBuildings", "CivicStructure", "Residence", "Accommodation",
+}
+
+// Address parts of a schema.org PostalAddress, in the order they are written.
+var addressParts = []string{"streetAddress", "addressLocality", "addressRegion", "postalCode", "addressCountry"}
+
+/*
+ExtractLocation extracts the geographic location of the page from (in order):
+  - The `geo` / `address` of a schema.org Place (or the `location` of an Event, etc)
+    in the structured data items, or a `GeoCoordinates` item
+  - The Open Graph `place:location:latitude` / `place:location:longitude` tags
+  - The `geo.position`, `ICBM` and `geo.placename` meta tags
+  - Coordinates in links to Google Maps, OpenStreetMap, Bing Maps and Apple Maps
+
+The coordinates come from the first source that has them, while the name and address
+are filled in from the later sources. Returns nil if no location is found.
+*/
+func ExtractLocation(doc *goquery.Document, items []*Item) *Location {
+	location := new(Location)
+
+	for _, item := range items {
+		location.merge(itemLocation(item, 0))
+	}
+
+	location.merge(parseCoordinates(
+		metaContent(doc, "meta[property='place:location:latitude'], meta[property='og:latitude']")+","+
+			metaContent(doc, "meta[property='place:location:longitude'], meta[property='og:longitude']"), "", ""))
+
+	location.merge(parseCoordinates(
+		metaContent(doc, "meta[name='geo.position'], meta[name='ICBM']"),
+		metaContent(doc, "meta[name='geo.placename']"), ""))
+
+	if location.hasCoordinates == false {
+		doc.Find("a[href*='maps'], a[href*='openstreetmap.org'], a[href*='goo.gl/maps']").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+			location.merge(mapLinkLocation(attr(s, "href")))
+			return location.hasCoordinates == false
+		})
+	}
+
+	if location.hasCoordinates == false && len(location.Name) == 0 && len(location.Address) == 0 {
+		return nil
+	}
+
+	return location
+}
+
+// MarshalJSON leaves out the coordinates of a location that has none (rather than giving 0,0).
+func (location Location) MarshalJSON() ([]byte, error) {
+	type plain Location
+	if location.hasCoordinates == true {
+		return json.Marshal(plain(location))
+	}
+
+	return json.Marshal(struct {
+		Name    string `json:"name,omitempty"`
+		Address string `json:"address,omitempty"`
+	}{location.Name, location.Address})
+}
+
+// merge fills in the missing details of the location from the other location.
+func (location *Location) merge(other *Location) {
+	if other == nil {
+		return
+	}
+
+	if location.hasCoordinates == false && other.hasCoordinates == true {
+		location.Latitude, location.Longitude, location.hasCoordinates = other.Latitude, other.Longitude, true
+	}
+
+	location.Name = firstNonEmptyString(location.Name, other.Name)
+	location.Address = firstNonEmptyString(location.Address, other.Address)
+}
+
+// itemLocation returns the location of a structured data item, following nested `location` properties.
+func itemLocation(item *Item, depth int) *Location {
+	if depth > 2 {
+		return nil
+	}
+
+	if item.Is("GeoCoordinates") {
+		return parseCoordinates(item.Text("latitude")+","+item.Text("longitude"), "", itemAddress(item))
+	}
+
+	location := new(Location)
+
+	if geo := item.Item("geo"); geo != nil {
+		location.merge(parseCoordinates(geo.Text("latitude")+","+geo.Text("longitude"), "", ""))
+	}
+	if len(item.Text("latitude")) > 0 {
+		location.merge(parseCoordinates(item.Text("latitude")+","+item.Text("longitude"), "", ""))
+	}
+
+	// Only places (and nested locations) - the address of an Organization is not where the page is about.
+	if location.hasCoordinates == true || depth > 0 || isPlace(item) {
+		location.Name = item.Text("name")
+		location.Address = itemAddress(item)
+	}
+
+	// eg: the location of an Event, or the contentLocation of a Photograph.
+	for _, name := range []string{"location", "contentLocation", "jobLocation", "spatialCoverage"} {
+		if nested := item.Item(name); nested != nil {
+			location.merge(itemLocation(nested, depth+1))
+		} else if len(location.Name) == 0 {
+			location.Name = item.String(name)
+		}
+	}
+
+	if location.hasCoordinates == false && len(location.Name) == 0 && len(location.Address) == 0 {
+		return nil
+	}
+
+	return location
+}
+
+func isPlace(item *Item) bool {
+	for _, placeType := range placeTypes {
+		if item.Is(placeType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// itemAddress formats the item's `address`, which can be given as text or as a PostalAddress.
+func itemAddress(item *Item) string {
+	address := item.Item("address")
+	if address == nil {
+		return item.String("address")
+	}
+
+	parts := []string{}
+	for _, name := range addressParts {
+		if part := address.Text(name); len(part) > 0 {
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// mapLinkLocation returns the location in a link to a map, eg: https://www.google.com/maps/@40.7,-74.0,15z
+func mapLinkLocation(href string) *Location {
+	mapURL, err := url.Parse(href)
+	if err != nil {
+		return nil
+	}
+
+	host := strings.ToLower(mapURL.Host)
+	isMap := strings.Contains(host, "google.") && strings.HasPrefix(mapURL.Path, "/maps") ||
+		strings.HasPrefix(host, "maps.") ||
+		strings.HasSuffix(host, "openstreetmap.org") ||
+		strings.HasSuffix(host, "bing.com") && strings.HasPrefix(mapURL.Path, "/maps")
+	if isMap == false {
+		return nil
+	}
+
+	if matches := mapAtCoordinate.FindStringSubmatch(mapURL.Path); matches != nil {
+		return parseCoordinates(matches[1]+","+matches[2], "", "")
+	}
+
+	if matches := osmMapFragment.FindStringSubmatch(mapURL.Fragment); matches != nil {
+		return parseCoordinates(matches[1]+","+matches[2], "", "")
+	}
+
+	query := mapURL.Query()
+	if lat, lng := query.Get("mlat"), query.Get("mlon"); len(lat) > 0 {
+		return parseCoordinates(lat+","+lng, "", "")
+	}
+
+	for _, name := range mapCoordinateParams {
+		if location := parseCoordinates(query.Get(name), "", ""); location != nil {
+			return location
+		}
+	}
+
+	return nil
+}
+
+/*
+NewLocation creates a location from its coordinates as a "lat,lng" pair (which may be
+empty), its name and its address. Returns nil if it has none of these.
+*/
+func NewLocation(coordinates string, name string, address string) *Location {
+	return parseCoordinates(coordinates, name, address)
+}
+
+/*
+parseCoordinates parses a "lat,lng" pair (also separated by `;`, `~` or a space).
+Returns a location without coordinates if they are invalid or out of range, or
+nil if there is nothing at all.
+*/
+func parseCoordinates(pair string, name string, address string) *Location {
+	location := &Location{Name: strings.TrimSpace(name), Address: strings.TrimSpace(address)}
+
+	if matches := coordinatePair.FindStringSubmatch(pair); matches != nil {
+		lat, latErr := strconv.ParseFloat(matches[1], 64)
+		lng, lngErr := strconv.ParseFloat(matches[2], 64)
+
+		// 0,0 is a placeholder rather than a location in the Gulf of Guinea.
+		if latErr == nil && lngErr == nil && math.Abs(lat) <= 90 && math.Abs(lng) <= 180 && (lat != 0 || lng != 0) {
+			location.Latitude, location.Longitude, location.hasCoordinates = lat, lng, true
+		}
+	}
+
+	if location.hasCoordinates == false && len(location.Name) == 0 && len(location.Address) == 0 {
+		return nil
+	}
+
+	return location
+}
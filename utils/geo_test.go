@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExtractLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			"schema.org place",
+			`<div itemscope itemtype="http://schema.org/Restaurant">
+				<span itemprop="name">A Restaurant</span>
+				<div itemprop="address" itemscope itemtype="http://schema.org/PostalAddress">
+					<span itemprop="streetAddress">1 Main St</span>, <span itemprop="addressLocality">Springfield</span>
+				</div>
+				<div itemprop="geo" itemscope itemtype="http://schema.org/GeoCoordinates">
+					<meta itemprop="latitude" content="40.7128"><meta itemprop="longitude" content="-74.0060">
+				</div>
+			</div>`,
+			`{"lat":40.7128,"lng":-74.006,"name":"A Restaurant","address":"1 Main St, Springfield"}`,
+		},
+		{
+			"event location",
+			`<div itemscope itemtype="http://schema.org/Event"><span itemprop="name">An Event</span>
+				<div itemprop="location" itemscope itemtype="http://schema.org/Place"><span itemprop="name">The Hall</span></div>
+			</div>
+			<meta name="geo.position" content="51.5;-0.12">`,
+			`{"lat":51.5,"lng":-0.12,"name":"The Hall"}`,
+		},
+		{
+			"organization address",
+			`<div itemscope itemtype="http://schema.org/Organization"><span itemprop="address">1 Main St</span></div>`,
+			`null`,
+		},
+		{
+			"open graph",
+			`<meta property="place:location:latitude" content="48.8584"><meta property="place:location:longitude" content="2.2945">
+			<meta name="geo.placename" content="Paris">`,
+			`{"lat":48.8584,"lng":2.2945,"name":"Paris"}`,
+		},
+		{
+			"map link",
+			`<a href="https://www.google.com/maps/place/Somewhere/@-33.8688,151.2093,15z">Map</a>`,
+			`{"lat":-33.8688,"lng":151.2093}`,
+		},
+		{
+			"placeholder coordinates",
+			`<meta name="ICBM" content="0, 0"><meta name="geo.placename" content="Nowhere">`,
+			`{"name":"Nowhere"}`,
+		},
+		{"none", `<a href="https://example.com/maps">Not a map</a>`, `null`},
+	}
+
+	for _, test := range tests {
+		doc := newTestDocument(t, "https://example.com/", "<html><head></head><body>"+test.html+"</body></html>")
+
+		if encoded, err := json.Marshal(ExtractLocation(doc, ExtractMicrodata(doc))); err != nil || string(encoded) != test.want {
+			t.Errorf("%s: ExtractLocation() = %s, want %s", test.name, encoded, test.want)
+		}
+	}
+}
+
+func TestMapLinkLocation(t *testing.T) {
+	tests := map[string]string{
+		"https://www.google.com/maps/@40.7,-74.0,15z":           `{"lat":40.7,"lng":-74}`,
+		"https://maps.google.com/?q=40.7,-74.0":                 `{"lat":40.7,"lng":-74}`,
+		"https://www.openstreetmap.org/#map=15/51.5074/-0.1278": `{"lat":51.5074,"lng":-0.1278}`,
+		"https://www.openstreetmap.org/?mlat=51.5&mlon=-0.12":   `{"lat":51.5,"lng":-0.12}`,
+		"https://www.bing.com/maps?cp=47.6~-122.3":              `{"lat":47.6,"lng":-122.3}`,
+		"https://maps.apple.com/?ll=37.33,-122.03":              `{"lat":37.33,"lng":-122.03}`,
+		"https://maps.google.com/?q=Some+Place":                 `null`,
+		"https://www.google.com/search?q=40.7,-74.0":            `null`,
+		"https://www.openstreetmap.org/#map=15/95.0/-0.1278":    `null`,
+	}
+
+	for href, want := range tests {
+		if encoded, _ := json.Marshal(mapLinkLocation(href)); string(encoded) != want {
+			t.Errorf("mapLinkLocation(%q) = %s, want %s", href, encoded, want)
+		}
+	}
+}
+
+func TestNewLocation(t *testing.T) {
+	tests := []struct {
+		coordinates string
+		name        string
+		address     string
+		want        string
+	}{
+		{"40.7, -74.0", " New York ", "", `{"lat":40.7,"lng":-74,"name":"New York"}`},
+		{"", "", "1 Main St", `{"address":"1 Main St"}`},
+		{"91,0", "", "", `null`},
+		{"", " ", "", `null`},
+	}
+
+	for _, test := range tests {
+		if encoded, _ := json.Marshal(NewLocation(test.coordinates, test.name, test.address)); string(encoded) != test.want {
+			t.Errorf("NewLocation(%q, %q, %q) = %s, want %s", test.coordinates, test.name, test.address, encoded, test.want)
+		}
+	}
+}
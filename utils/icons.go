@@ -0,0 +1,213 @@
+package utils
+
+import (
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Icon is a site icon candidate.
+type Icon struct {
+	URL   string `json:"url"`
+	Rel   string `json:"rel"`
+	Type  string `json:"type,omitempty"`
+	Sizes string `json:"sizes,omitempty"`
+
+	// Largest declared size in pixels - 0 if unknown. SVG icons of size `any` are scalable.
+	Width    int  `json:"width,omitempty"`
+	Height   int  `json:"height,omitempty"`
+	Scalable bool `json:"scalable,omitempty"`
+
+	// Only for `mask-icon`
+	Color string `json:"color,omitempty"`
+}
+
+/*
+ExtractIcons discovers the site icons declared by the document using `<link rel="icon">`,
+`shortcut icon`, `apple-touch-icon`, `apple-touch-icon-precomposed` and `mask-icon`,
+as well as the icons listed in the (optional) web app manifest.
+
+The icons are ranked best first: larger declared sizes first, with the preferred
+image types (PNG, SVG, ICO, others) breaking ties. Monochrome `mask-icon`s are always
+ranked last since they need to be tinted to be usable.
+
+If no icons are declared at all, `/favicon.ico` is returned as the fallback.
+*/
+func ExtractIcons(doc *goquery.Document, manifest *Manifest) []Icon {
+	icons := []Icon{}
+	seen := make(map[string]bool)
+
+	add := func(icon Icon) {
+		if len(icon.URL) == 0 || seen[icon.URL] == true {
+			return
+		}
+		seen[icon.URL] = true
+
+		if len(icon.Type) == 0 {
+			icon.Type = iconTypeFromURL(icon.URL)
+		}
+		icon.Width, icon.Height, icon.Scalable = parseIconSizes(icon.Sizes)
+
+		icons = append(icons, icon)
+	}
+
+	doc.Find("link[rel][href]").Each(func(_ int, s *goquery.Selection) {
+		if rel := iconRel(attr(s, "rel")); len(rel) > 0 {
+			href, _ := s.Attr("href")
+			iconType, _ := s.Attr("type")
+			sizes, _ := s.Attr("sizes")
+			color, _ := s.Attr("color")
+
+			add(Icon{
+				URL:   ResolveURL(doc, href),
+				Rel:   rel,
+				Type:  strings.ToLower(strings.TrimSpace(iconType)),
+				Sizes: strings.TrimSpace(sizes),
+				Color: strings.TrimSpace(color),
+			})
+		}
+	})
+
+	if manifest != nil {
+		for _, icon := range manifest.Icons {
+			add(Icon{
+				URL:   icon.Src,
+				Rel:   "manifest",
+				Type:  strings.ToLower(icon.Type),
+				Sizes: icon.Sizes,
+			})
+		}
+	}
+
+	if len(icons) == 0 {
+		add(Icon{URL: ResolveURL(doc, "/favicon.ico"), Rel: "icon"})
+		return icons
+	}
+
+	sort.SliceStable(icons, func(i, j int) bool {
+		a, b := icons[i], icons[j]
+
+		if (a.Rel == "mask-icon") != (b.Rel == "mask-icon") {
+			return b.Rel == "mask-icon"
+		}
+
+		if sizeA, sizeB := iconRankSize(a), iconRankSize(b); sizeA != sizeB {
+			return sizeA > sizeB
+		}
+
+		return iconTypeRank(a.Type) < iconTypeRank(b.Type)
+	})
+
+	return icons
+}
+
+/*
+iconRel returns the kind of icon for the `rel` attribute, which is a list of tokens in
+any order (eg: "alternate icon", "icon shortcut"): one of `icon`, `shortcut icon`,
+`apple-touch-icon`, `apple-touch-icon-precomposed` or `mask-icon`. Returns an empty
+string if it is not an icon.
+*/
+func iconRel(rel string) string {
+	tokens := make(map[string]bool)
+	for _, token := range strings.Fields(strings.ToLower(rel)) {
+		tokens[token] = true
+	}
+
+	for _, kind := range []string{"mask-icon", "apple-touch-icon-precomposed", "apple-touch-icon"} {
+		if tokens[kind] == true {
+			return kind
+		}
+	}
+
+	if tokens["icon"] == true {
+		if tokens["shortcut"] == true {
+			return "shortcut icon"
+		}
+		return "icon"
+	}
+
+	return ""
+}
+
+// iconRankSize returns the size used to rank the icon, assuming the platform defaults for undeclared sizes.
+func iconRankSize(icon Icon) int {
+	if icon.Scalable == true {
+		// Can be rendered at any size we want.
+		return 1 << 16
+	}
+
+	if size := icon.Width; size > 0 {
+		return size
+	}
+
+	if strings.HasPrefix(icon.Rel, "apple-touch-icon") {
+		// iOS requires these to be at least 180x180 these days.
+		return 180
+	}
+
+	return 16
+}
+
+func iconTypeRank(iconType string) int {
+	switch iconType {
+	case "image/png":
+		return 0
+	case "image/svg+xml":
+		return 1
+	case "image/x-icon", "image/vnd.microsoft.icon":
+		return 2
+	}
+
+	return 3
+}
+
+func iconTypeFromURL(iconURL string) string {
+	if index := strings.IndexAny(iconURL, "?#"); index >= 0 {
+		iconURL = iconURL[:index]
+	}
+
+	switch strings.ToLower(path.Ext(iconURL)) {
+	case ".png":
+		return "image/png"
+	case ".svg":
+		return "image/svg+xml"
+	case ".ico":
+		return "image/x-icon"
+	case ".gif":
+		return "image/gif"
+	case ".jpg", ".jpeg":
+		return "image/jpeg"
+	case ".webp":
+		return "image/webp"
+	}
+
+	return ""
+}
+
+// parseIconSizes parses a `sizes` attribute (eg: "16x16 32x32" or "any") and returns the largest size.
+func parseIconSizes(sizes string) (int, int, bool) {
+	width, height, scalable := 0, 0, false
+
+	for _, size := range strings.Fields(strings.ToLower(sizes)) {
+		if size == "any" {
+			scalable = true
+			continue
+		}
+
+		dimensions := strings.SplitN(size, "x", 2)
+		if len(dimensions) != 2 {
+			continue
+		}
+
+		w, errW := strconv.Atoi(dimensions[0])
+		h, errH := strconv.Atoi(dimensions[1])
+		if errW == nil && errH == nil && w*h > width*height {
+			width, height = w, h
+		}
+	}
+
+	return width, height, scalable
+}
@@ -0,0 +1,106 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestExtractIcons(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/page", `<html><head>
+		<link rel="icon" href="/favicon.ico">
+		<link rel="ICON" type="image/png" sizes="16x16 32x32" href="/icon-32.png">
+		<link rel="alternate icon" href="/icon.svg" sizes="any">
+		<link rel="apple-touch-icon" href="/touch.png">
+		<link rel="mask-icon" href="/mask.svg" color="#000000">
+		<link rel="icon" href="data:image/png;base64,AAAA">
+		<link rel="stylesheet" href="/style.css">
+		<link rel="icon" href="/favicon.ico">
+	</head><body></body></html>`)
+
+	manifest := &Manifest{Icons: []ManifestIcon{{Src: "https://example.com/icon-512.png", Sizes: "512x512", Type: "image/png"}}}
+
+	want := []Icon{
+		{URL: "https://example.com/icon.svg", Rel: "icon", Type: "image/svg+xml", Sizes: "any", Scalable: true},
+		{URL: "https://example.com/icon-512.png", Rel: "manifest", Type: "image/png", Sizes: "512x512", Width: 512, Height: 512},
+		{URL: "https://example.com/touch.png", Rel: "apple-touch-icon", Type: "image/png"},
+		{URL: "https://example.com/icon-32.png", Rel: "icon", Type: "image/png", Sizes: "16x16 32x32", Width: 32, Height: 32},
+		{URL: "https://example.com/favicon.ico", Rel: "icon", Type: "image/x-icon"},
+		{URL: "https://example.com/mask.svg", Rel: "mask-icon", Type: "image/svg+xml", Color: "#000000"},
+	}
+
+	if icons := ExtractIcons(doc, manifest); reflect.DeepEqual(icons, want) == false {
+		t.Errorf("ExtractIcons() = %+v, want %+v", icons, want)
+	}
+}
+
+func TestExtractIconsFallback(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/some/page", `<html><head></head><body></body></html>`)
+
+	want := []Icon{{URL: "https://example.com/favicon.ico", Rel: "icon", Type: "image/x-icon"}}
+	if icons := ExtractIcons(doc, nil); reflect.DeepEqual(icons, want) == false {
+		t.Errorf("ExtractIcons() = %+v, want %+v", icons, want)
+	}
+}
+
+func TestIconRel(t *testing.T) {
+	tests := map[string]string{
+		"icon":                         "icon",
+		"shortcut icon":                "shortcut icon",
+		"Icon Shortcut":                "shortcut icon",
+		"alternate icon":               "icon",
+		"apple-touch-icon":             "apple-touch-icon",
+		"apple-touch-icon-precomposed": "apple-touch-icon-precomposed",
+		"mask-icon":                    "mask-icon",
+		"stylesheet":                   "",
+		"icons":                        "",
+	}
+
+	for rel, want := range tests {
+		if kind := iconRel(rel); kind != want {
+			t.Errorf("iconRel(%q) = %q, want %q", rel, kind, want)
+		}
+	}
+}
+
+func TestFetchManifest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/app/manifest.json" {
+			http.NotFound(w, r)
+			return
+		}
+
+		fmt.Fprint(w, `{"name": "Example App", "short_name": "Example", "theme_color": "#336699", "icons": [
+			{"src": "icon-192.png", "sizes": "192x192", "type": "image/png"},
+			{"src": "data:image/png;base64,AAAA", "sizes": "16x16"}
+		]}`)
+	}))
+	defer server.Close()
+
+	doc := newTestDocument(t, server.URL+"/page", `<html><head><link rel="manifest" href="/app/manifest.json"></head><body></body></html>`)
+
+	manifest, err := FetchManifest(doc)
+	if err != nil {
+		t.Fatalf("FetchManifest() returned an error: %v", err)
+	}
+
+	want := &Manifest{
+		URL: server.URL + "/app/manifest.json", Name: "Example App", ShortName: "Example", ThemeColor: "#336699",
+		Icons: []ManifestIcon{{Src: server.URL + "/app/icon-192.png", Sizes: "192x192", Type: "image/png"}},
+	}
+	if reflect.DeepEqual(manifest, want) == false {
+		t.Errorf("FetchManifest() = %+v, want %+v", manifest, want)
+	}
+
+	for _, body := range []string{
+		`<html><head></head><body></body></html>`,
+		`<html><head><link rel="manifest" href="/missing.json"></head><body></body></html>`,
+		`<html><head><link rel="manifest" href="javascript:void(0)"></head><body></body></html>`,
+	} {
+		if manifest, err := FetchManifest(newTestDocument(t, server.URL+"/page", body)); err == nil {
+			t.Errorf("FetchManifest(%q) = %+v, want an error", body, manifest)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Image is a candidate image for the page's thumbnail.
+type Image struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	Alt    string `json:"alt,omitempty"`
+
+	score float64
+}
+
+// Images smaller than this (in either dimension) are icons, tracking pixels, etc.
+const minImageSize = 50
+
+// Maximum aspect ratio (either way) for an image to be usable as a thumbnail - beyond this it is a banner or a sprite.
+const maxImageAspectRatio = 4
+
+// Number of bytes fetched when probing the size of an image.
+const imageProbeBytes = 64 * 1024
+
+// Maximum number of images probed for their size.
+const maxImageProbes = 3
+
+var (
+	junkImageNames = regexp.MustCompile(`(?i)icon|logo|avatar|sprite|pixel|tracking|tracker|spinner|loading|loader|emoji|badge|button|banner|spacer|blank|placeholder|advert|\bads?\b|gravatar|share|social`)
+	junkImageURLs  = regexp.MustCompile(`(?i)1x1|pixel|beacon|tracking|spacer|blank\.gif|transparent\.gif|doubleclick|googleads|/ads/|facebook\.com/tr|\.svg(\?|$)`)
+	srcsetWidth    = regexp.MustCompile(`^(\d+)w$`)
+)
+
+/*
+ExtractBestImage picks the best image to represent the page, for when it declares
+none in its meta tags. Candidates are the `<img>` elements (including the common
+lazy loading attributes and `srcset`) and the `link[rel=image_src]`.
+
+Tracking pixels, sprites, icons, etc are filtered out by their declared size, class
+names and URL patterns. The rest are ranked by size, with images inside the main
+content, earlier in the page and with alt text preferred.
+
+If `probe` is true, the sizes of the top candidates that don't declare their size
+are determined by fetching just the first bytes of the images (see ProbeImageSize).
+
+Returns nil if there is no suitable image.
+*/
+func ExtractBestImage(doc *goquery.Document, probe bool) *Image {
+	candidates := []*Image{}
+	seen := make(map[string]bool)
+
+	add := func(candidate *Image) {
+		if len(candidate.URL) == 0 || seen[candidate.URL] == true {
+			return
+		}
+		seen[candidate.URL] = true
+
+		candidates = append(candidates, candidate)
+	}
+
+	if href, exists := doc.Find("link[rel='image_src']").First().Attr("href"); exists == true {
+		add(&Image{URL: ResolveURL(doc, href), score: 2})
+	}
+
+	doc.Find("img").Each(func(index int, s *goquery.Selection) {
+		if s.ParentsFiltered("header, nav, footer, aside").Length() > 0 {
+			return
+		}
+
+		names := strings.Join([]string{attr(s, "class"), attr(s, "id"), attr(s, "alt")}, " ")
+		if junkImageNames.MatchString(names) {
+			return
+		}
+
+		candidate := &Image{
+			URL:    ResolveURL(doc, imageSource(s)),
+			Width:  parseDimension(attr(s, "width")),
+			Height: parseDimension(attr(s, "height")),
+			Alt:    strings.TrimSpace(attr(s, "alt")),
+		}
+
+		if junkImageURLs.MatchString(candidate.URL) {
+			return
+		}
+
+		// Preference for the images in the main content, earlier in the page and described.
+		candidate.score = 1 / (1 + float64(index)*0.1)
+		if s.ParentsFiltered("article, main, [itemprop~='articleBody'], .h-entry").Length() > 0 {
+			candidate.score *= 2
+		}
+		if len(candidate.Alt) > 0 {
+			candidate.score *= 1.1
+		}
+		if strings.Contains(strings.ToLower(candidate.URL), ".gif") {
+			candidate.score *= 0.5
+		}
+
+		add(candidate)
+	})
+
+	candidates = rankImages(candidates)
+
+	if probe == true {
+		probes := 0
+		for _, candidate := range candidates {
+			if probes >= maxImageProbes {
+				break
+			}
+
+			if candidate.Width == 0 || candidate.Height == 0 {
+				probes++
+				if width, height, err := ProbeImageSize(candidate.URL); err == nil {
+					candidate.Width, candidate.Height = width, height
+				}
+			}
+		}
+
+		candidates = rankImages(candidates)
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return candidates[0]
+}
+
+/*
+ProbeImageSize determines the dimensions of a (GIF, JPEG or PNG) image by fetching
+only its first bytes - just enough to decode the image header.
+*/
+func ProbeImageSize(imageURL string) (int, int, error) {
+	request, err := http.NewRequest("GET", imageURL, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	request.Header.Set("Range", fmt.Sprintf("bytes=0-%d", imageProbeBytes-1))
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusPartialContent {
+		return 0, 0, errors.New(fmt.Sprint("Unable to fetch image: ", response.Status))
+	}
+
+	config, _, err := image.DecodeConfig(io.LimitReader(response.Body, imageProbeBytes))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return config.Width, config.Height, nil
+}
+
+// rankImages drops the images that are too small or too oblong, and sorts the rest best first.
+func rankImages(candidates []*Image) []*Image {
+	ranked := []*Image{}
+
+	for _, candidate := range candidates {
+		width, height := candidate.Width, candidate.Height
+
+		if (width > 0 && width < minImageSize) || (height > 0 && height < minImageSize) {
+			continue
+		}
+
+		if width > 0 && height > 0 &&
+			(width > height*maxImageAspectRatio || height > width*maxImageAspectRatio) {
+			continue
+		}
+
+		ranked = append(ranked, candidate)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return imageRank(ranked[i]) > imageRank(ranked[j])
+	})
+
+	return ranked
+}
+
+func imageRank(candidate *Image) float64 {
+	// Images of unknown size are assumed to be of a typical content image size (300x200).
+	area := 300.0 * 200.0
+	if candidate.Width > 0 && candidate.Height > 0 {
+		area = float64(candidate.Width * candidate.Height)
+	}
+
+	return area * candidate.score
+}
+
+// imageSource returns the source URL of an `<img>`, handling the common lazy loading attributes and `srcset`.
+func imageSource(s *goquery.Selection) string {
+	for _, name := range []string{"data-src", "data-lazy-src", "data-original"} {
+		if src := attr(s, name); len(src) > 0 {
+			return src
+		}
+	}
+
+	if src := attr(s, "src"); len(src) > 0 && strings.HasPrefix(src, "data:") == false {
+		return src
+	}
+
+	// The widest image in the srcset
+	best, bestWidth := "", -1
+	for _, candidate := range strings.Split(attr(s, "srcset"), ",") {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+
+		width := 0
+		if len(fields) > 1 {
+			if matches := srcsetWidth.FindStringSubmatch(fields[1]); matches != nil {
+				width, _ = strconv.Atoi(matches[1])
+			}
+		}
+
+		if width > bestWidth {
+			best, bestWidth = fields[0], width
+		}
+	}
+
+	return best
+}
+
+// parseDimension parses a width/height attribute like "300" or "300px". Returns 0 for anything else (eg: "100%").
+func parseDimension(value string) int {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "px")
+
+	if dimension, err := strconv.Atoi(value); err == nil && dimension > 0 {
+		return dimension
+	}
+
+	return 0
+}
+
+func attr(s *goquery.Selection, name string) string {
+	value, _ := s.Attr(name)
+	return value
+}
@@ -0,0 +1,108 @@
+package utils
+
+import (
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestExtractBestImage(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			"largest declared size",
+			`<img src="/small.jpg" width="200" height="150"><img src="/large.jpg" width="800" height="600">`,
+			"https://example.com/large.jpg",
+		},
+		{
+			"junk filtered out",
+			`<header><img src="/header.jpg" width="800" height="600"></header>
+			<img src="/logo.png" class="site-logo" width="800" height="600">
+			<img src="/pixel.gif?id=1" width="1" height="1">
+			<img src="/tiny.jpg" width="20" height="20">
+			<img src="/banner.jpg" width="1000" height="100">
+			<img src="/photo.jpg">`,
+			"https://example.com/photo.jpg",
+		},
+		{
+			"main content preferred",
+			`<img src="/outside.jpg"><article><p>Text</p><img src="/inside.jpg"></article>`,
+			"https://example.com/inside.jpg",
+		},
+		{
+			"lazy loading and srcset",
+			`<img src="data:image/gif;base64,R0lGOD" srcset="/small.jpg 320w, /large.jpg 1024w">`,
+			"https://example.com/large.jpg",
+		},
+		{
+			"lazy loading attribute",
+			`<img src="/placeholder.jpg" data-src="/real.jpg">`,
+			"https://example.com/real.jpg",
+		},
+		{"none", `<img src="/icon.png" class="icon">`, ""},
+	}
+
+	for _, test := range tests {
+		doc := newTestDocument(t, "https://example.com/page", "<html><body>"+test.body+"</body></html>")
+
+		url := ""
+		if best := ExtractBestImage(doc, false); best != nil {
+			url = best.URL
+		}
+
+		if url != test.want {
+			t.Errorf("%s: ExtractBestImage() = %q, want %q", test.name, url, test.want)
+		}
+	}
+}
+
+func TestExtractBestImageProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// eg: /300x200.png
+		size := strings.Split(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/"), ".png"), "x")
+		if len(size) != 2 {
+			http.NotFound(w, r)
+			return
+		}
+
+		width, _ := strconv.Atoi(size[0])
+		height, _ := strconv.Atoi(size[1])
+
+		png.Encode(w, image.NewGray(image.Rect(0, 0, width, height)))
+	}))
+	defer server.Close()
+
+	width, height, err := ProbeImageSize(server.URL + "/640x480.png")
+	if err != nil || width != 640 || height != 480 {
+		t.Errorf("ProbeImageSize() = %d, %d, %v, want 640, 480", width, height, err)
+	}
+
+	if _, _, err := ProbeImageSize(server.URL + "/missing"); err == nil {
+		t.Error("ProbeImageSize() of a missing image returned no error")
+	}
+
+	doc := newTestDocument(t, server.URL+"/page", `<html><body>
+		<img src="/100x100.png"><img src="/10x10.png"><img src="/800x600.png">
+	</body></html>`)
+
+	if best := ExtractBestImage(doc, true); best == nil || best.URL != server.URL+"/800x600.png" || best.Width != 800 || best.Height != 600 {
+		t.Errorf("ExtractBestImage() with probing = %+v, want the 800x600 image", best)
+	}
+}
+
+func TestParseDimension(t *testing.T) {
+	tests := map[string]int{"300": 300, " 300px ": 300, "100%": 0, "auto": 0, "-5": 0, "": 0}
+
+	for value, want := range tests {
+		if dimension := parseDimension(value); dimension != want {
+			t.Errorf("parseDimension(%q) = %d, want %d", value, dimension, want)
+		}
+	}
+}
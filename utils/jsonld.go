@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+/*
+ExtractJSONLD extracts the items from the JSON-LD (http://json-ld.org/) blocks in
+the document, ie, `<script type="application/ld+json">`.
+
+Both top-level arrays and `@graph` containers are flattened to a list of items.
+Node references (eg: `"author": {"@id": "#/schema/person/1"}`, as used by Yoast) are
+resolved to the nodes with that `@id` in any of the blocks. Each node is converted
+once and shared by the references to it, which marshal to just its type and ID (see
+Item.MarshalJSON). Keyword properties (`@context`, `@type`, etc) are dropped - `@type`
+and `@id` are available as the item's Type and ID. Scalar values are converted to
+strings so that the items can be handled just like Microdata items. Invalid blocks
+are ignored.
+*/
+func ExtractJSONLD(doc *goquery.Document) []*Item {
+	blocks := []interface{}{}
+	graph := &jsonLDGraph{
+		nodes:     make(map[string]map[string]interface{}),
+		resolving: make(map[string]bool),
+		converted: make(map[string]*Item),
+	}
+
+	doc.Find("script[type='application/ld+json']").Each(func(_ int, s *goquery.Selection) {
+		var data interface{}
+
+		if err := json.Unmarshal([]byte(s.Text()), &data); err != nil {
+			return
+		}
+
+		blocks = append(blocks, data)
+		graph.collect(data)
+	})
+
+	items := []*Item{}
+	for _, data := range blocks {
+		items = append(items, graph.items(data)...)
+	}
+
+	return items
+}
+
+// jsonLDGraph holds the nodes (objects with an `@id` and other properties) of the JSON-LD blocks, to resolve references.
+type jsonLDGraph struct {
+	nodes     map[string]map[string]interface{}
+	resolving map[string]bool  // Nodes being converted - to break reference cycles
+	converted map[string]*Item // Referenced nodes already converted - shared by all the references to them
+}
+
+// collect adds the nodes in the data to the graph. The first node with an `@id` wins.
+func (graph *jsonLDGraph) collect(data interface{}) {
+	switch value := data.(type) {
+	case []interface{}:
+		for _, element := range value {
+			graph.collect(element)
+		}
+
+	case map[string]interface{}:
+		if id, ok := value["@id"].(string); ok == true && len(value) > 1 && graph.nodes[id] == nil {
+			graph.nodes[id] = value
+		}
+
+		for _, element := range value {
+			graph.collect(element)
+		}
+	}
+}
+
+// items returns the top-level items in the JSON-LD data.
+func (graph *jsonLDGraph) items(data interface{}) []*Item {
+	items := []*Item{}
+
+	switch value := data.(type) {
+	case []interface{}:
+		for _, element := range value {
+			items = append(items, graph.items(element)...)
+		}
+
+	case map[string]interface{}:
+		if nested, exists := value["@graph"]; exists == true {
+			items = append(items, graph.items(nested)...)
+		} else {
+			items = append(items, graph.item(value))
+		}
+	}
+
+	return items
+}
+
+func (graph *jsonLDGraph) item(object map[string]interface{}) *Item {
+	item := newItem()
+
+	id, _ := object["@id"].(string)
+	if len(id) > 0 {
+		graph.resolving[id] = true
+		defer delete(graph.resolving, id)
+	}
+
+	for key, value := range object {
+		switch key {
+		case "@type":
+			item.Type = graph.stringValues(value)
+
+		case "@id":
+			item.ID = id
+
+		default:
+			if strings.HasPrefix(key, "@") {
+				continue
+			}
+
+			for _, propertyValue := range graph.values(value) {
+				item.addProperty(SchemaTypeName(key), propertyValue)
+			}
+		}
+	}
+
+	return item
+}
+
+// values converts a JSON-LD value to a list of property values (strings or *Item).
+func (graph *jsonLDGraph) values(value interface{}) []interface{} {
+	values := []interface{}{}
+
+	switch v := value.(type) {
+	case []interface{}:
+		for _, element := range v {
+			values = append(values, graph.values(element)...)
+		}
+
+	case map[string]interface{}:
+		// Value objects, eg: {"@value": "2015-01-01", "@type": "Date"}
+		if literal, exists := v["@value"]; exists == true {
+			return graph.values(literal)
+		}
+
+		// Node references, eg: {"@id": "#/schema/person/1"} - unless it refers to a node being converted.
+		// Each node is converted once, however many times it is referenced.
+		if id, ok := v["@id"].(string); ok == true && len(v) == 1 {
+			if item, exists := graph.converted[id]; exists == true {
+				return append(values, item)
+			}
+
+			if node, exists := graph.nodes[id]; exists == true && graph.resolving[id] == false {
+				item := graph.item(node)
+				item.reference = true
+				graph.converted[id] = item
+
+				return append(values, item)
+			}
+		}
+
+		values = append(values, graph.item(v))
+
+	case string:
+		values = append(values, v)
+
+	case float64:
+		values = append(values, strconv.FormatFloat(v, 'f', -1, 64))
+
+	case bool:
+		values = append(values, strconv.FormatBool(v))
+	}
+
+	return values
+}
+
+func (graph *jsonLDGraph) stringValues(value interface{}) []string {
+	strs := []string{}
+
+	for _, element := range graph.values(value) {
+		if str, ok := element.(string); ok == true {
+			strs = append(strs, str)
+		}
+	}
+
+	return strs
+}
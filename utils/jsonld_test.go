@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExtractJSONLD(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/posts/1", `<html><head>
+		<script type="application/ld+json">
+		{"@context": "https://schema.org", "@graph": [
+			{"@type": "Article", "@id": "#article", "headline": "A Headline", "wordCount": 1200, "isAccessibleForFree": true,
+				"author": {"@id": "#person"}, "datePublished": {"@value": "2015-03-01", "@type": "Date"}},
+			{"@type": ["Person", "Thing"], "@id": "#person", "name": "Jane Doe", "knows": {"@id": "#article"}}
+		]}
+		</script>
+		<script type="application/ld+json">[{"@type": "BreadcrumbList"}, {"@type": "WebSite", "publisher": {"@id": "#unknown"}}]</script>
+		<script type="application/ld+json">{ invalid </script>
+	</head><body></body></html>`)
+
+	items := ExtractJSONLD(doc)
+	if len(items) != 4 {
+		t.Fatalf("ExtractJSONLD() returned %d items, want 4", len(items))
+	}
+
+	article := items[0]
+	if article.Is("Article") == false || article.ID != "#article" {
+		t.Errorf("Article item = %+v", article)
+	}
+
+	properties := map[string]string{
+		"headline":            "A Headline",
+		"wordCount":           "1200",
+		"isAccessibleForFree": "true",
+		"datePublished":       "2015-03-01",
+		"author":              "Jane Doe",
+	}
+	for name, want := range properties {
+		if value := article.Text(name); value != want {
+			t.Errorf("Article property %q = %q, want %q", name, value, want)
+		}
+	}
+
+	// The reference back to the article (which is being converted) is not resolved.
+	if knows := article.Item("author").Item("knows"); knows == nil || knows.ID != "#article" || len(knows.Properties) > 0 {
+		t.Errorf("Reference cycle resolved to %+v, want just the reference", knows)
+	}
+
+	if person := items[1]; person.Is("Person") == false || person.Is("Thing") == false || person.String("name") != "Jane Doe" {
+		t.Errorf("Person item = %+v", person)
+	}
+
+	if publisher := items[3].Item("publisher"); publisher == nil || publisher.ID != "#unknown" {
+		t.Errorf("Unknown reference = %+v, want just the reference", publisher)
+	}
+}
+
+func TestExtractJSONLDSharedReferences(t *testing.T) {
+	// Every node refers to the next one twice - expanding the references each time
+	// would double the work (and the output) for every node.
+	nodes := []string{}
+	for i := 0; i < 40; i++ {
+		nodes = append(nodes, fmt.Sprintf(`{"@id": "#n%d", "@type": "Thing", "a": {"@id": "#n%d"}, "b": {"@id": "#n%d"}}`, i, i+1, i+1))
+	}
+
+	doc := newTestDocument(t, "https://example.com/", `<html><head><script type="application/ld+json">{"@graph": [`+
+		strings.Join(nodes, ",")+`]}</script></head><body></body></html>`)
+
+	items := ExtractJSONLD(doc)
+	if len(items) != 40 {
+		t.Fatalf("ExtractJSONLD() returned %d items, want 40", len(items))
+	}
+
+	if a, b := items[0].Item("a"), items[0].Item("b"); a == nil || a != b || a.Item("a") == nil {
+		t.Errorf("The references to #n1 were not resolved to the same item: %+v, %+v", a, b)
+	}
+
+	encoded, err := json.Marshal(items[0])
+	if err != nil {
+		t.Fatalf("Unable to marshal the item: %v", err)
+	}
+
+	want := `{"type":["Thing"],"id":"#n0","properties":{"a":[{"type":["Thing"],"id":"#n1"}],"b":[{"type":["Thing"],"id":"#n1"}]}}`
+	if string(encoded) != want {
+		t.Errorf("Marshaled item = %s, want %s", encoded, want)
+	}
+}
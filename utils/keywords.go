@@ -0,0 +1,203 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Additional English stop words used to split the text into candidate keyphrases.
+var keyphraseStopWords = []string{
+	"a", "about", "after", "all", "also", "an", "any", "at", "because", "been", "before", "being", "but",
+	"can", "could", "did", "do", "does", "had", "has", "he", "her", "here", "him", "his", "how", "i", "if",
+	"into", "its", "just", "like", "many", "may", "me", "more", "most", "much", "my", "new", "no", "not",
+	"now", "only", "or", "other", "our", "out", "over", "said", "says", "she", "should", "so", "some",
+	"such", "than", "their", "them", "then", "there", "these", "they", "those", "through", "up", "us",
+	"very", "we", "were", "what", "when", "where", "which", "while", "who", "why", "will", "would", "your",
+}
+
+// Maximum number of words in a keyphrase.
+const maxKeyphraseWords = 3
+
+/*
+ExtractKeywords extracts the keywords and tags declared by the page in
+`meta[name=keywords]`, `meta[name=news_keywords]`, `article:tag`, `rel=tag` links,
+microformats2 `p-category` and the `keywords` of the structured data items.
+
+The keywords are deduplicated (ignoring case) in that order.
+*/
+func ExtractKeywords(doc *goquery.Document, items []*Item) []string {
+	keywords := []string{}
+
+	doc.Find("meta[name='keywords'], meta[name='news_keywords']").Each(func(_ int, s *goquery.Selection) {
+		content, _ := s.Attr("content")
+		keywords = append(keywords, strings.Split(content, ",")...)
+	})
+
+	doc.Find("meta[property='article:tag']").Each(func(_ int, s *goquery.Selection) {
+		content, _ := s.Attr("content")
+		keywords = append(keywords, content)
+	})
+
+	doc.Find("a[rel~='tag'], .h-entry .p-category").Each(func(_ int, s *goquery.Selection) {
+		keywords = append(keywords, s.Text())
+	})
+
+	for _, item := range items {
+		for _, value := range item.Properties["keywords"] {
+			// Either a list of keywords, or a single comma separated string.
+			if str, ok := value.(string); ok == true {
+				keywords = append(keywords, strings.Split(str, ",")...)
+			}
+		}
+	}
+
+	return DedupeStrings(keywords)
+}
+
+/*
+ExtractKeyphrases extracts up to `count` keyphrases from the text using RAKE
+(Rapid Automatic Keyword Extraction): the text is split into candidate phrases at
+punctuation and stop words, each word is scored by its degree/frequency ratio, and
+the phrases are ranked by the sum of their word scores.
+
+The stop words of the given language (if known) are used along with the English ones.
+*/
+func ExtractKeyphrases(text string, language string, count int) []string {
+	stops := make(map[string]bool)
+	for _, word := range keyphraseStopWords {
+		stops[word] = true
+	}
+	for _, lang := range []string{"en", strings.Split(language, "-")[0]} {
+		for _, word := range stopWords[lang] {
+			stops[word] = true
+		}
+	}
+
+	// Split into candidate phrases
+	phrases := [][]string{}
+	current := []string{}
+
+	flush := func() {
+		if len(current) > 0 && len(current) <= maxKeyphraseWords {
+			phrases = append(phrases, current)
+		}
+		current = []string{}
+	}
+
+	for _, token := range strings.FieldsFunc(text, unicode.IsSpace) {
+		word := strings.TrimFunc(token, func(r rune) bool {
+			return unicode.IsLetter(r) == false && unicode.IsNumber(r) == false
+		})
+		lower := strings.ToLower(word)
+
+		if len(word) < 3 || stops[lower] == true || isNumber(word) {
+			flush()
+			continue
+		}
+
+		current = append(current, lower)
+
+		// Punctuation at the end of a token ends the phrase.
+		if last := token[len(token)-1:]; strings.ContainsAny(last, ".,;:!?)\"") {
+			flush()
+		}
+	}
+	flush()
+
+	// Word scores
+	frequency := make(map[string]int)
+	degree := make(map[string]int)
+	for _, phrase := range phrases {
+		for _, word := range phrase {
+			frequency[word]++
+			degree[word] += len(phrase)
+		}
+	}
+
+	// Phrase scores - repeated phrases are counted once and weighted by their count.
+	type scored struct {
+		phrase  string
+		score   float64
+		count   int
+		support int // Highest frequency of the words in the phrase
+	}
+	scores := make(map[string]*scored)
+	ranked := []*scored{}
+
+	for _, phrase := range phrases {
+		key := strings.Join(phrase, " ")
+
+		if existing, ok := scores[key]; ok == true {
+			existing.count++
+			continue
+		}
+
+		candidate := &scored{phrase: key, count: 1}
+		for _, word := range phrase {
+			candidate.score += float64(degree[word]) / float64(frequency[word])
+			if frequency[word] > candidate.support {
+				candidate.support = frequency[word]
+			}
+		}
+
+		scores[key] = candidate
+		ranked = append(ranked, candidate)
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i].score*float64(ranked[i].count), ranked[j].score*float64(ranked[j].count)
+		if a != b {
+			return a > b
+		}
+		return ranked[i].phrase < ranked[j].phrase
+	})
+
+	keyphrases := []string{}
+	for _, candidate := range ranked {
+		if len(keyphrases) >= count {
+			break
+		}
+
+		// Words mentioned only once are not what the text is about.
+		if candidate.support < 2 {
+			continue
+		}
+
+		keyphrases = append(keyphrases, candidate.phrase)
+	}
+
+	return keyphrases
+}
+
+// DedupeStrings trims the strings and removes the empty ones and duplicates (ignoring case), preserving the order.
+func DedupeStrings(values []string) []string {
+	deduped := []string{}
+	seen := make(map[string]bool)
+
+	for _, value := range values {
+		value = strings.Join(strings.Fields(value), " ")
+		key := strings.ToLower(value)
+
+		if len(value) == 0 || seen[key] == true {
+			continue
+		}
+		seen[key] = true
+
+		deduped = append(deduped, value)
+	}
+
+	return deduped
+}
+
+func isNumber(word string) bool {
+	for _, r := range word {
+		if unicode.IsNumber(r) == false && r != '.' && r != ',' {
+			return false
+		}
+	}
+
+	return true
+}
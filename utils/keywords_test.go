@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractKeywords(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/", `<html><head>
+		<meta name="keywords" content="Go, scraping , , metadata">
+		<meta name="news_keywords" content="go,  Web   Pages">
+		<meta property="article:tag" content="Parsing">
+	</head><body>
+		<a rel="tag" href="/tags/html">HTML</a>
+		<div class="h-entry"><span class="p-category">Microformats</span></div>
+		<span class="p-category">Not in an entry</span>
+	</body></html>`)
+
+	item := newItem()
+	item.addProperty("keywords", "JSON-LD, parsing")
+	item.addProperty("keywords", "Schema.org")
+
+	want := []string{"Go", "scraping", "metadata", "Web Pages", "Parsing", "HTML", "Microformats", "JSON-LD", "Schema.org"}
+	if keywords := ExtractKeywords(doc, []*Item{item}); reflect.DeepEqual(keywords, want) == false {
+		t.Errorf("ExtractKeywords() = %q, want %q", keywords, want)
+	}
+}
+
+func TestExtractKeyphrases(t *testing.T) {
+	text := "Compatibility of systems of linear constraints over the set of natural numbers. " +
+		"Criteria of compatibility of a system of linear Diophantine equations, strict inequations, " +
+		"and nonstrict inequations are considered. Upper bounds for components of a minimal set of " +
+		"solutions and algorithms of construction of minimal generating sets of solutions for all types " +
+		"of systems are given. These criteria and the corresponding algorithms for constructing a minimal " +
+		"supporting set of solutions can be used in solving all the considered types of systems and systems of mixed types."
+
+	tests := []struct {
+		language string
+		count    int
+		want     []string
+	}{
+		{"en", 3, []string{"minimal generating sets", "linear diophantine equations", "minimal supporting set"}},
+		{"en", 1, []string{"minimal generating sets"}},
+		{"", 0, []string{}},
+	}
+
+	for _, test := range tests {
+		if keyphrases := ExtractKeyphrases(text, test.language, test.count); reflect.DeepEqual(keyphrases, test.want) == false {
+			t.Errorf("ExtractKeyphrases(%q, %d) = %q, want %q", test.language, test.count, keyphrases, test.want)
+		}
+	}
+
+	if keyphrases := ExtractKeyphrases("Every word here is mentioned only once.", "en", 5); len(keyphrases) != 0 {
+		t.Errorf("ExtractKeyphrases() = %q for words mentioned once, want none", keyphrases)
+	}
+}
+
+func TestDedupeStrings(t *testing.T) {
+	want := []string{"One", "two words", "Three"}
+	if deduped := DedupeStrings([]string{" One", "two  words", "", "one", "TWO WORDS ", "Three", "  "}); reflect.DeepEqual(deduped, want) == false {
+		t.Errorf("DedupeStrings() = %q, want %q", deduped, want)
+	}
+}
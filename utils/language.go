@@ -0,0 +1,255 @@
+package utils
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Language is the detected language of a page.
+type Language struct {
+	Tag        string  `json:"tag"`        // BCP 47 language tag, eg: en-US
+	Confidence float64 `json:"confidence"` // Between 0 and 1
+}
+
+// Confidence assigned to a declared language, depending on whether the text agrees with it.
+const (
+	declaredConfidence   = 0.9
+	confirmedConfidence  = 1.0
+	contradictConfidence = 0.5
+)
+
+// Maximum amount of text used for detection.
+const maxDetectionLength = 10000
+
+// Frequent function words of the languages written in the Latin script, used to tell them apart.
+var stopWords = map[string][]string{
+	"en": {"the", "and", "of", "to", "in", "is", "that", "for", "it", "with", "was", "on", "are", "as", "this", "be", "by", "have", "from", "you"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "ein", "eine", "zu", "den", "mit", "sich", "auf", "für", "von", "dem", "auch", "wird", "des", "sie"},
+	"fr": {"le", "la", "les", "et", "des", "est", "une", "du", "que", "pour", "dans", "qui", "pas", "sur", "au", "avec", "ce", "sont", "par", "il"},
+	"es": {"el", "la", "los", "las", "que", "y", "en", "del", "es", "por", "una", "para", "con", "no", "se", "su", "al", "como", "más", "pero"},
+	"it": {"il", "di", "che", "è", "la", "per", "non", "un", "una", "del", "della", "sono", "le", "con", "si", "gli", "da", "nel", "anche", "ma"},
+	"pt": {"o", "de", "que", "e", "do", "da", "em", "não", "um", "uma", "para", "com", "os", "no", "na", "se", "por", "mais", "as", "são"},
+	"nl": {"de", "het", "een", "en", "van", "is", "dat", "niet", "in", "op", "te", "zijn", "met", "voor", "die", "ook", "er", "maar", "aan", "wordt"},
+	"sv": {"och", "det", "att", "en", "som", "är", "på", "för", "med", "har", "den", "av", "inte", "till", "var", "jag", "om", "ett", "men", "de"},
+	"da": {"og", "det", "at", "en", "er", "til", "på", "de", "med", "for", "af", "ikke", "den", "som", "har", "et", "var", "jeg", "han", "om"},
+	"pl": {"i", "w", "nie", "się", "na", "to", "że", "z", "jest", "do", "jak", "ale", "co", "tak", "za", "od", "po", "czy", "są", "przez"},
+	"tr": {"ve", "bir", "bu", "da", "de", "için", "ile", "çok", "olarak", "daha", "gibi", "ne", "var", "olan", "ama", "kadar", "sonra", "ben", "en", "mi"},
+	"id": {"yang", "dan", "di", "ini", "itu", "dengan", "untuk", "tidak", "dari", "dalam", "akan", "pada", "juga", "ke", "ada", "saya", "bisa", "karena", "oleh", "atau"},
+}
+
+/*
+ExtractLanguage determines the language of the page from the declared `<html lang>`,
+the `Content-Language` header and `og:locale` (in that order), and checks it against
+a statistical detection on the text. If the text is empty, the page body is used.
+
+A declared language gets a confidence of 0.9, which is raised to 1 if the text agrees
+with it or lowered to 0.5 if it clearly doesn't. If no language is declared, the
+detected one is returned with its own confidence. Returns nil if the language can't
+be determined.
+*/
+func ExtractLanguage(doc *goquery.Document, response *http.Response, text string) *Language {
+	declared := ""
+
+	if lang, exists := doc.Find("html").First().Attr("lang"); exists == true {
+		declared = NormalizeLanguageTag(lang)
+	}
+
+	if len(declared) == 0 {
+		if header := response.Header.Get("Content-Language"); len(header) > 0 {
+			declared = NormalizeLanguageTag(strings.Split(header, ",")[0])
+		}
+	}
+
+	if len(declared) == 0 {
+		declared = NormalizeLanguageTag(metaContent(doc, "meta[property='og:locale']"))
+	}
+
+	if len(strings.TrimSpace(text)) == 0 {
+		text = doc.Find("body").Text()
+	}
+	detected := DetectLanguage(text)
+
+	if len(declared) == 0 {
+		return detected
+	}
+
+	language := &Language{Tag: declared, Confidence: declaredConfidence}
+
+	if detected != nil {
+		primary := strings.Split(declared, "-")[0]
+
+		if detected.Tag == primary {
+			language.Confidence = confirmedConfidence
+		} else if detected.Confidence >= 0.8 {
+			language.Confidence = contradictConfidence
+		}
+	}
+
+	return language
+}
+
+/*
+NormalizeLanguageTag normalizes language tags and locales to the BCP 47 form, eg:
+`en_us` => `en-US`, `zh-hant-tw` => `zh-Hant-TW`. Returns an empty string for
+values that are not language tags.
+*/
+func NormalizeLanguageTag(tag string) string {
+	subtags := strings.FieldsFunc(strings.TrimSpace(tag), func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+
+	if len(subtags) == 0 {
+		return ""
+	}
+
+	for _, r := range subtags[0] {
+		if unicode.IsLetter(r) == false || r > unicode.MaxASCII {
+			return ""
+		}
+	}
+	if length := len(subtags[0]); length < 2 || length > 3 {
+		return ""
+	}
+
+	for i, subtag := range subtags {
+		switch {
+		case i == 0:
+			subtags[i] = strings.ToLower(subtag)
+		case len(subtag) == 4 && unicode.IsLetter(rune(subtag[0])):
+			// Script, eg: Hant
+			subtags[i] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		case len(subtag) == 2:
+			// Region, eg: US
+			subtags[i] = strings.ToUpper(subtag)
+		default:
+			subtags[i] = strings.ToLower(subtag)
+		}
+	}
+
+	return strings.Join(subtags, "-")
+}
+
+/*
+DetectLanguage detects the (primary) language of the text statistically. The script
+of the text decides the language for most non-Latin scripts, while languages using
+the Latin script are told apart by the frequency of their common function words.
+
+Returns nil if there isn't enough text to tell.
+*/
+func DetectLanguage(text string) *Language {
+	if len(text) > maxDetectionLength {
+		text = text[:maxDetectionLength]
+	}
+
+	if language := detectByScript(text); language != nil {
+		return language
+	}
+
+	counts := make(map[string]int)
+	total := 0
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return unicode.IsLetter(r) == false
+	}) {
+		total++
+
+		for lang, words := range stopWords {
+			for _, stopWord := range words {
+				if word == stopWord {
+					counts[lang]++
+					break
+				}
+			}
+		}
+	}
+
+	best, bestCount, hits := "", 0, 0
+	for lang, count := range counts {
+		hits += count
+		if count > bestCount || (count == bestCount && lang < best) {
+			best, bestCount = lang, count
+		}
+	}
+
+	if bestCount < 3 || total < 10 {
+		return nil
+	}
+
+	// The share of the hits for the best language, discounted when there is little evidence.
+	confidence := float64(bestCount) / float64(hits) * math.Min(1, float64(bestCount)/20)
+
+	return &Language{Tag: best, Confidence: math.Floor(confidence*100) / 100}
+}
+
+// detectByScript detects the languages that can be told apart by their script alone.
+func detectByScript(text string) *Language {
+	scripts := make(map[string]int)
+	letters := 0
+
+	for _, r := range text {
+		if unicode.IsLetter(r) == false {
+			continue
+		}
+		letters++
+
+		switch {
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			scripts["ja"]++
+		case unicode.Is(unicode.Hangul, r):
+			scripts["ko"]++
+		case unicode.Is(unicode.Han, r):
+			scripts["zh"]++
+		case unicode.Is(unicode.Arabic, r):
+			scripts["ar"]++
+			if strings.ContainsRune("پچژگ", r) {
+				scripts["fa"]++
+			}
+		case unicode.Is(unicode.Cyrillic, r):
+			scripts["ru"]++
+			if strings.ContainsRune("іїєґІЇЄҐ", r) {
+				scripts["uk"]++
+			}
+		case unicode.Is(unicode.Greek, r):
+			scripts["el"]++
+		case unicode.Is(unicode.Hebrew, r):
+			scripts["he"]++
+		case unicode.Is(unicode.Thai, r):
+			scripts["th"]++
+		case unicode.Is(unicode.Devanagari, r):
+			scripts["hi"]++
+		}
+	}
+
+	if letters < 10 {
+		return nil
+	}
+
+	// Japanese mixes Kanji with Kana, and Persian/Ukrainian use letters specific to them.
+	if scripts["ja"] > 0 {
+		scripts["ja"] += scripts["zh"]
+	}
+	for specific, general := range map[string]string{"fa": "ar", "uk": "ru"} {
+		if scripts[specific] > 0 {
+			scripts[specific] = scripts[general]
+			delete(scripts, general)
+		}
+	}
+
+	best, bestCount := "", 0
+	for lang, count := range scripts {
+		if count > bestCount || (count == bestCount && lang < best) {
+			best, bestCount = lang, count
+		}
+	}
+
+	share := float64(bestCount) / float64(letters)
+	if share < 0.5 {
+		return nil
+	}
+
+	return &Language{Tag: best, Confidence: math.Floor(share*100) / 100}
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"net/http"
+	"testing"
+)
+
+const (
+	englishText = "The quick brown fox jumps over the lazy dog, and this is the story of how it was told to the children in the village by the old man."
+	germanText  = "Der schnelle braune Fuchs springt über den faulen Hund, und das ist die Geschichte, die sich im Dorf von dem alten Mann erzählt wird."
+)
+
+func TestNormalizeLanguageTag(t *testing.T) {
+	tests := map[string]string{
+		"en":         "en",
+		"EN_us":      "en-US",
+		" en-gb ":    "en-GB",
+		"zh-hant-tw": "zh-Hant-TW",
+		"de-DE-1996": "de-DE-1996",
+		"fil":        "fil",
+		"":           "",
+		"e":          "",
+		"english":    "",
+		"12-US":      "",
+		"é-FR":       "",
+	}
+
+	for tag, want := range tests {
+		if normalized := NormalizeLanguageTag(tag); normalized != want {
+			t.Errorf("NormalizeLanguageTag(%q) = %q, want %q", tag, normalized, want)
+		}
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{englishText, "en"},
+		{germanText, "de"},
+		{"Le renard brun rapide saute par-dessus le chien paresseux, et c'est une histoire que les enfants du village aiment pour la fin.", "fr"},
+		{"東京は日本の首都であり、世界で最も人口の多い都市の一つです。", "ja"},
+		{"Москва является столицей России и крупнейшим городом страны.", "ru"},
+		{"Київ є столицею України та найбільшим містом країни.", "uk"},
+		{"Short text", ""},
+		{"1234 5678 !!!", ""},
+	}
+
+	for _, test := range tests {
+		detected := DetectLanguage(test.text)
+
+		tag := ""
+		if detected != nil {
+			tag = detected.Tag
+			if detected.Confidence <= 0 || detected.Confidence > 1 {
+				t.Errorf("DetectLanguage(%q) confidence = %v, want it between 0 and 1", test.text, detected.Confidence)
+			}
+		}
+
+		if tag != test.want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", test.text, tag, test.want)
+		}
+	}
+}
+
+func TestExtractLanguage(t *testing.T) {
+	tests := []struct {
+		html   string
+		header string
+		want   Language
+	}{
+		{`<html lang="en-us"><body><p>` + englishText + `</p></body></html>`, "", Language{"en-US", confirmedConfidence}},
+		{`<html lang="en"><body><p>Москва является столицей России и крупнейшим городом страны.</p></body></html>`, "", Language{"en", contradictConfidence}},
+		{`<html><body><p>Hello</p></body></html>`, "de-DE, en", Language{"de-DE", declaredConfidence}},
+		{`<html><head><meta property="og:locale" content="fr_FR"></head><body></body></html>`, "", Language{"fr-FR", declaredConfidence}},
+		{`<html><body><p>` + germanText + `</p></body></html>`, "", Language{"de", 0}},
+		{`<html><body><p>Hello</p></body></html>`, "", Language{}},
+	}
+
+	for _, test := range tests {
+		doc := newTestDocument(t, "https://example.com/", test.html)
+		response := &http.Response{Header: http.Header{}}
+		if len(test.header) > 0 {
+			response.Header.Set("Content-Language", test.header)
+		}
+
+		language := ExtractLanguage(doc, response, "")
+
+		if len(test.want.Tag) == 0 {
+			if language != nil {
+				t.Errorf("ExtractLanguage(%q) = %+v, want nil", test.html, language)
+			}
+		} else if language == nil || language.Tag != test.want.Tag || (test.want.Confidence > 0 && language.Confidence != test.want.Confidence) {
+			t.Errorf("ExtractLanguage(%q) = %+v, want %+v", test.html, language, test.want)
+		}
+	}
+}
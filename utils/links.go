@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Pagination holds the links to the next and previous pages of a multi-page article, listing, etc.
+type Pagination struct {
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+// LanguageAlternate is a version of the page in another language (or for another region).
+type LanguageAlternate struct {
+	URL      string `json:"url"`
+	Language string `json:"language"` // BCP 47 language tag, or `x-default`
+}
+
+// ExtractPagination extracts the `rel=next` and `rel=prev` (or `rel=previous`) links. Returns nil if there are none.
+func ExtractPagination(doc *goquery.Document) *Pagination {
+	pagination := &Pagination{
+		Next: relLink(doc, "next"),
+		Prev: firstNonEmptyString(relLink(doc, "prev"), relLink(doc, "previous")),
+	}
+
+	if len(pagination.Next) == 0 && len(pagination.Prev) == 0 {
+		return nil
+	}
+
+	return pagination
+}
+
+// ExtractLanguageAlternates extracts the versions of the page in other languages, from `<link rel="alternate" hreflang>`.
+func ExtractLanguageAlternates(doc *goquery.Document) []LanguageAlternate {
+	alternates := []LanguageAlternate{}
+	seen := make(map[string]bool)
+
+	doc.Find("link[rel~='alternate'][hreflang][href]").Each(func(_ int, s *goquery.Selection) {
+		alternateURL := ResolveURL(doc, attr(s, "href"))
+
+		language := strings.ToLower(strings.TrimSpace(attr(s, "hreflang")))
+		if language != "x-default" {
+			language = NormalizeLanguageTag(language)
+		}
+
+		if len(alternateURL) == 0 || len(language) == 0 || seen[language] == true {
+			return
+		}
+		seen[language] = true
+
+		alternates = append(alternates, LanguageAlternate{URL: alternateURL, Language: language})
+	})
+
+	return alternates
+}
+
+// ExtractAMPURL extracts the URL of the AMP version of the page, from `<link rel="amphtml">`.
+func ExtractAMPURL(doc *goquery.Document) string {
+	return relLink(doc, "amphtml")
+}
+
+// ExtractMobileURL extracts the URL of the separate mobile version of the page, from `<link rel="alternate" media>`.
+func ExtractMobileURL(doc *goquery.Document) string {
+	mobileURL := ""
+
+	doc.Find("link[rel~='alternate'][media][href]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if media := strings.ToLower(attr(s, "media")); strings.Contains(media, "max-width") || strings.Contains(media, "handheld") {
+			mobileURL = ResolveURL(doc, attr(s, "href"))
+		}
+
+		return len(mobileURL) == 0
+	})
+
+	return mobileURL
+}
+
+/*
+MatchLanguageAlternate finds the alternate for the preferred language: one with the
+exact language tag (ignoring case), or else one with the same primary language (eg:
+`en-GB` for `en`, or `en` for `en-US`). Returns nil if there is none.
+*/
+func MatchLanguageAlternate(alternates []LanguageAlternate, preferred string) *LanguageAlternate {
+	preferred = NormalizeLanguageTag(preferred)
+	if len(preferred) == 0 {
+		return nil
+	}
+
+	for i, alternate := range alternates {
+		if alternate.Language == preferred {
+			return &alternates[i]
+		}
+	}
+
+	for i, alternate := range alternates {
+		if LanguageMatches(alternate.Language, preferred) {
+			return &alternates[i]
+		}
+	}
+
+	return nil
+}
+
+// LanguageMatches checks if the two language tags are of the same primary language, eg: `en-US` and `en-GB`.
+func LanguageMatches(tag string, other string) bool {
+	primary := strings.Split(NormalizeLanguageTag(tag), "-")[0]
+
+	return len(primary) > 0 && primary == strings.Split(NormalizeLanguageTag(other), "-")[0]
+}
+
+// relLink returns the (resolved) URL of the first `<link>` or `<a>` with the given `rel`.
+func relLink(doc *goquery.Document, rel string) string {
+	href, _ := doc.Find("link[rel~='" + rel + "'][href], a[rel~='" + rel + "'][href]").First().Attr("href")
+	return ResolveURL(doc, href)
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractPagination(t *testing.T) {
+	tests := []struct {
+		html string
+		want *Pagination
+	}{
+		{`<link rel="next" href="/page/3"><link rel="prev" href="/page/1">`, &Pagination{Next: "https://example.com/page/3", Prev: "https://example.com/page/1"}},
+		{`<a rel="previous" href="?page=1">Previous</a>`, &Pagination{Prev: "https://example.com/articles/?page=1"}},
+		{`<link rel="nofollow next" href="2">`, &Pagination{Next: "https://example.com/articles/2"}},
+		{`<link rel="canonical" href="/articles/">`, nil},
+	}
+
+	for _, test := range tests {
+		doc := newTestDocument(t, "https://example.com/articles/", "<html><head>"+test.html+"</head></html>")
+
+		if got := ExtractPagination(doc); reflect.DeepEqual(got, test.want) == false {
+			t.Errorf("ExtractPagination(%s) = %+v, want %+v", test.html, got, test.want)
+		}
+	}
+}
+
+func TestExtractLanguageAlternates(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/page", `<html><head>
+		<link rel="alternate" hreflang="en-us" href="/en-us/page">
+		<link rel="alternate" hreflang="de" href="https://example.de/seite">
+		<link rel="alternate" hreflang="EN-US" href="/duplicate">
+		<link rel="alternate" hreflang="x-default" href="/page">
+		<link rel="alternate" hreflang="fr" href="">
+		<link rel="alternate" type="application/rss+xml" href="/feed">
+	</head></html>`)
+
+	want := []LanguageAlternate{
+		{URL: "https://example.com/en-us/page", Language: "en-US"},
+		{URL: "https://example.de/seite", Language: "de"},
+		{URL: "https://example.com/page", Language: "x-default"},
+	}
+
+	if got := ExtractLanguageAlternates(doc); reflect.DeepEqual(got, want) == false {
+		t.Errorf("ExtractLanguageAlternates() = %+v, want %+v", got, want)
+	}
+}
+
+func TestExtractAMPAndMobileURL(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/page", `<html><head>
+		<link rel="alternate" media="print" href="/print/page">
+		<link rel="alternate" media="only screen and (max-width: 640px)" href="https://m.example.com/page">
+		<link rel="amphtml" href="/amp/page">
+	</head></html>`)
+
+	if got := ExtractAMPURL(doc); got != "https://example.com/amp/page" {
+		t.Errorf("ExtractAMPURL() = %q", got)
+	}
+
+	if got := ExtractMobileURL(doc); got != "https://m.example.com/page" {
+		t.Errorf("ExtractMobileURL() = %q", got)
+	}
+
+	doc = newTestDocument(t, "https://example.com/page", `<html><head></head></html>`)
+	if got := ExtractAMPURL(doc) + ExtractMobileURL(doc); got != "" {
+		t.Errorf("ExtractAMPURL() + ExtractMobileURL() = %q, want none", got)
+	}
+}
+
+func TestMatchLanguageAlternate(t *testing.T) {
+	alternates := []LanguageAlternate{
+		{URL: "https://example.com/en-gb/", Language: "en-GB"},
+		{URL: "https://example.com/en-us/", Language: "en-US"},
+		{URL: "https://example.com/pt/", Language: "pt"},
+		{URL: "https://example.com/", Language: "x-default"},
+	}
+
+	tests := map[string]string{
+		"en-us": "https://example.com/en-us/",
+		"en":    "https://example.com/en-gb/",
+		"en-AU": "https://example.com/en-gb/",
+		"pt-BR": "https://example.com/pt/",
+		"de":    "",
+		"":      "",
+	}
+
+	for preferred, want := range tests {
+		got := ""
+		if alternate := MatchLanguageAlternate(alternates, preferred); alternate != nil {
+			got = alternate.URL
+		}
+
+		if got != want {
+			t.Errorf("MatchLanguageAlternate(%q) = %q, want %q", preferred, got, want)
+		}
+	}
+}
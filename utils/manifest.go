@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+/*
+Manifest holds the parts of a web app manifest (https://www.w3.org/TR/appmanifest/)
+that we use.
+*/
+type Manifest struct {
+	URL             string         `json:"url"`
+	Name            string         `json:"name"`
+	ShortName       string         `json:"short_name"`
+	ThemeColor      string         `json:"theme_color"`
+	BackgroundColor string         `json:"background_color"`
+	Icons           []ManifestIcon `json:"icons"`
+}
+
+type ManifestIcon struct {
+	Src     string `json:"src"`
+	Sizes   string `json:"sizes"`
+	Type    string `json:"type"`
+	Purpose string `json:"purpose"`
+}
+
+/*
+FetchManifest fetches and parses the web app manifest linked from the document
+using `<link rel="manifest">`. Icon URLs are resolved against the manifest URL, and
+icons that aren't http(s) URLs (eg: `data:` URLs) are dropped. The manifest is
+fetched with a timeout and a size limit.
+
+Returns an error if there is no manifest linked or it can't be fetched/parsed.
+*/
+func FetchManifest(doc *goquery.Document) (*Manifest, error) {
+	href, exists := doc.Find("link[rel~='manifest']").First().Attr("href")
+	if exists == false {
+		return nil, errors.New("No manifest linked.")
+	}
+
+	manifestURL, err := url.Parse(ResolveURL(doc, href))
+	if err != nil || len(manifestURL.Host) == 0 {
+		return nil, errors.New(fmt.Sprint("Invalid manifest URL: ", href))
+	}
+
+	body, err := fetch(manifestURL.String())
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := new(Manifest)
+	if err := json.Unmarshal(body, manifest); err != nil {
+		return nil, err
+	}
+
+	manifest.URL = manifestURL.String()
+	icons := []ManifestIcon{}
+	for _, icon := range manifest.Icons {
+		if src, err := manifestURL.Parse(icon.Src); err == nil && len(HTTPURL(src.String())) > 0 {
+			icon.Src = src.String()
+			icons = append(icons, icon)
+		}
+	}
+	manifest.Icons = icons
+
+	return manifest, nil
+}
@@ -0,0 +1,223 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+/*
+ExtractMicrodata extracts the HTML5 Microdata items present in the document,
+following the WHATWG algorithm (https://html.spec.whatwg.org/multipage/microdata.html).
+
+Only top-level items (ie, `itemscope` elements which are not themselves the value
+of an `itemprop`) are returned. Nested items are available as property values.
+`itemref` references are followed, and cyclical references are dropped.
+*/
+func ExtractMicrodata(doc *goquery.Document) []*Item {
+	items := []*Item{}
+
+	parser := newMicrodataParser(doc)
+
+	doc.Find("[itemscope]").Not("[itemprop]").Each(func(_ int, s *goquery.Selection) {
+		for _, node := range s.Nodes {
+			items = append(items, parser.item(node))
+		}
+	})
+
+	return items
+}
+
+type microdataParser struct {
+	doc *goquery.Document
+
+	// Position of each element in tree order, used to sort the properties.
+	order map[*html.Node]int
+
+	// Elements by their `id`, used to resolve `itemref`.
+	ids map[string]*html.Node
+
+	// Items currently being built, used to detect cycles.
+	memory map[*html.Node]bool
+}
+
+func newMicrodataParser(doc *goquery.Document) *microdataParser {
+	parser := &microdataParser{
+		doc:    doc,
+		order:  make(map[*html.Node]int),
+		ids:    make(map[string]*html.Node),
+		memory: make(map[*html.Node]bool),
+	}
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode {
+			parser.order[node] = len(parser.order)
+
+			if id, exists := nodeAttr(node, "id"); exists == true {
+				if _, seen := parser.ids[id]; seen == false {
+					parser.ids[id] = node
+				}
+			}
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+
+	for _, node := range doc.Nodes {
+		walk(node)
+	}
+
+	return parser
+}
+
+func (parser *microdataParser) item(node *html.Node) *Item {
+	item := newItem()
+
+	itemType, _ := nodeAttr(node, "itemtype")
+	item.Type = strings.Fields(itemType)
+
+	if itemID, exists := nodeAttr(node, "itemid"); exists == true {
+		item.ID = resolveReference(parser.doc, itemID)
+	}
+
+	parser.memory[node] = true
+	defer delete(parser.memory, node)
+
+	for _, element := range parser.properties(node) {
+		itemProp, _ := nodeAttr(element, "itemprop")
+
+		for _, name := range strings.Fields(itemProp) {
+			if _, isScope := nodeAttr(element, "itemscope"); isScope == true && parser.memory[element] == true {
+				// Cyclical reference - drop it.
+				continue
+			}
+
+			item.addProperty(name, parser.value(element))
+		}
+	}
+
+	return item
+}
+
+// properties crawls the item's subtree (and the elements it references through
+// `itemref`) and returns the property elements in tree order.
+func (parser *microdataParser) properties(root *html.Node) []*html.Node {
+	results := []*html.Node{}
+	seen := map[*html.Node]bool{root: true}
+
+	pending := childElements(root)
+
+	if itemRef, exists := nodeAttr(root, "itemref"); exists == true {
+		for _, id := range strings.Fields(itemRef) {
+			if element, ok := parser.ids[id]; ok == true {
+				pending = append(pending, element)
+			}
+		}
+	}
+
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+
+		if seen[current] == true {
+			continue
+		}
+		seen[current] = true
+
+		if _, isScope := nodeAttr(current, "itemscope"); isScope == false {
+			pending = append(pending, childElements(current)...)
+		}
+
+		if itemProp, exists := nodeAttr(current, "itemprop"); exists == true && len(strings.TrimSpace(itemProp)) > 0 {
+			results = append(results, current)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return parser.order[results[i]] < parser.order[results[j]]
+	})
+
+	return results
+}
+
+// value returns the property value of the element as per the rules in the spec.
+func (parser *microdataParser) value(element *html.Node) interface{} {
+	if _, isScope := nodeAttr(element, "itemscope"); isScope == true {
+		return parser.item(element)
+	}
+
+	switch element.Data {
+	case "meta":
+		content, _ := nodeAttr(element, "content")
+		return content
+
+	case "audio", "embed", "iframe", "img", "source", "track", "video":
+		return parser.urlValue(element, "src")
+
+	case "a", "area", "link":
+		return parser.urlValue(element, "href")
+
+	case "object":
+		return parser.urlValue(element, "data")
+
+	case "data", "meter":
+		value, _ := nodeAttr(element, "value")
+		return value
+
+	case "time":
+		if dateTime, exists := nodeAttr(element, "datetime"); exists == true {
+			return dateTime
+		}
+	}
+
+	return nodeText(element)
+}
+
+func (parser *microdataParser) urlValue(element *html.Node, attr string) string {
+	if value, exists := nodeAttr(element, attr); exists == true {
+		return resolveReference(parser.doc, value)
+	}
+
+	return ""
+}
+
+func nodeAttr(node *html.Node, name string) (string, bool) {
+	for _, attr := range node.Attr {
+		if attr.Namespace == "" && attr.Key == name {
+			return attr.Val, true
+		}
+	}
+
+	return "", false
+}
+
+func childElements(node *html.Node) []*html.Node {
+	children := []*html.Node{}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode {
+			children = append(children, child)
+		}
+	}
+
+	return children
+}
+
+// nodeText returns the textContent of the node.
+func nodeText(node *html.Node) string {
+	if node.Type == html.TextNode {
+		return node.Data
+	}
+
+	var text strings.Builder
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		text.WriteString(nodeText(child))
+	}
+
+	return text.String()
+}
@@ -0,0 +1,121 @@
+package utils
+
+import "testing"
+
+func TestExtractMicrodata(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/products/1", `<html><body>
+		<div itemscope itemtype="http://schema.org/Product" itemid="#product" itemref="extra">
+			<h1 itemprop="name"> A Product </h1>
+			<img itemprop="image" src="/images/1.jpg">
+			<a itemprop="url" href="1">Link</a>
+			<meta itemprop="sku" content="SKU1">
+			<data itemprop="gtin" value="123">GTIN</data>
+			<time itemprop="releaseDate" datetime="2015-03-01">1 March</time>
+			<div itemprop="offers" itemscope itemtype="http://schema.org/Offer">
+				<span itemprop="price">12.50</span>
+				<span itemprop="priceCurrency">USD</span>
+			</div>
+			<span itemprop="category keywords">Things</span>
+		</div>
+		<p id="extra" itemprop="description">Referenced</p>
+		<div itemscope itemtype="http://schema.org/Person"><span itemprop="name">Someone</span></div>
+	</body></html>`)
+
+	items := ExtractMicrodata(doc)
+	if len(items) != 2 {
+		t.Fatalf("ExtractMicrodata() returned %d items, want 2", len(items))
+	}
+
+	product := items[0]
+	if product.Is("Product") == false || product.Is("http://schema.org/Product") == false || product.Is("Offer") == true {
+		t.Errorf("Product item has the type %v", product.Type)
+	}
+	if product.ID != "https://example.com/products/1#product" {
+		t.Errorf("Product item has the ID %q", product.ID)
+	}
+
+	properties := map[string]string{
+		"name":        "A Product",
+		"image":       "https://example.com/images/1.jpg",
+		"url":         "https://example.com/products/1",
+		"sku":         "SKU1",
+		"gtin":        "123",
+		"releaseDate": "2015-03-01",
+		"category":    "Things",
+		"keywords":    "Things",
+		"description": "Referenced",
+	}
+	for name, want := range properties {
+		if value := product.String(name); value != want {
+			t.Errorf("Product property %q = %q, want %q", name, value, want)
+		}
+	}
+
+	offer := product.Item("offers")
+	if offer == nil || offer.Is("Offer") == false || offer.String("price") != "12.50" || offer.String("priceCurrency") != "USD" {
+		t.Errorf("Product offers = %+v", offer)
+	}
+	if product.Text("offers") != "" {
+		t.Errorf("Text of an item without a name = %q, want an empty string", product.Text("offers"))
+	}
+
+	if person := items[1]; person.Is("Person") == false || person.String("name") != "Someone" {
+		t.Errorf("Person item = %+v", person)
+	}
+}
+
+func TestExtractMicrodataCycles(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/", `<html><body>
+		<div id="a" itemscope itemtype="http://schema.org/Thing" itemref="b">
+			<span itemprop="name">A</span>
+		</div>
+		<div id="b" itemprop="related" itemscope itemref="a"><span itemprop="name">B</span></div>
+	</body></html>`)
+
+	items := ExtractMicrodata(doc)
+	if len(items) != 1 {
+		t.Fatalf("ExtractMicrodata() returned %d items, want 1", len(items))
+	}
+
+	related := items[0].Item("related")
+	if related == nil || related.String("name") != "B" {
+		t.Fatalf("Related item = %+v", related)
+	}
+
+	if _, cyclical := related.Properties["related"]; cyclical == true {
+		t.Errorf("The cyclical reference back to the item was kept: %+v", related.Properties)
+	}
+}
+
+func TestItemText(t *testing.T) {
+	author := newItem()
+	author.addProperty("name", " Someone ")
+
+	item := newItem()
+	item.addProperty("headline", "  ")
+	item.addProperty("headline", "A Headline")
+	item.addProperty("author", author)
+
+	tests := map[string]string{"headline": "A Headline", "author": "Someone", "missing": ""}
+	for name, want := range tests {
+		if text := item.Text(name); text != want {
+			t.Errorf("Text(%q) = %q, want %q", name, text, want)
+		}
+	}
+}
+
+func TestSchemaTypeName(t *testing.T) {
+	tests := map[string]string{
+		"http://schema.org/Product":  "Product",
+		"https://schema.org/Product": "Product",
+		"schema:Product":             "Product",
+		"http://example.com/#Thing":  "Thing",
+		"Product":                    "Product",
+	}
+
+	for itemType, want := range tests {
+		if name := SchemaTypeName(itemType); name != want {
+			t.Errorf("SchemaTypeName(%q) = %q, want %q", itemType, name, want)
+		}
+	}
+}
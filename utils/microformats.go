@@ -0,0 +1,499 @@
+package utils
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+/*
+Microformat represents a microformats2 item (http://microformats.org/wiki/microformats2-parsing),
+eg: an `h-entry` or an `h-card`. It marshals to the canonical microformats2 JSON.
+
+Property values are either strings, nested *Microformat values (for property
+microformats like `p-author h-card`) or *EmbeddedContent values (for `e-*` properties).
+*/
+type Microformat struct {
+	Type       []string                 `json:"type"`
+	Properties map[string][]interface{} `json:"properties"`
+	Children   []*Microformat           `json:"children,omitempty"`
+
+	// Value is set only for microformats that are nested as a property value.
+	Value string `json:"value,omitempty"`
+}
+
+// EmbeddedContent is the value of an `e-*` property.
+type EmbeddedContent struct {
+	HTML  string `json:"html"`
+	Value string `json:"value"`
+}
+
+// Is checks if the microformat is of the given root type, eg: `h-entry`.
+func (mf *Microformat) Is(typeName string) bool {
+	for _, mfType := range mf.Type {
+		if mfType == typeName {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+String returns the first value of the given property as plain text. Nested
+microformats are represented by their value and embedded content by its text.
+*/
+func (mf *Microformat) String(name string) string {
+	for _, value := range mf.Properties[name] {
+		switch v := value.(type) {
+		case string:
+			return v
+		case *Microformat:
+			return v.Value
+		case *EmbeddedContent:
+			return v.Value
+		}
+	}
+
+	return ""
+}
+
+// Microformat returns the first nested microformat value of the given property, if any.
+func (mf *Microformat) Microformat(name string) *Microformat {
+	for _, value := range mf.Properties[name] {
+		if nested, ok := value.(*Microformat); ok == true {
+			return nested
+		}
+	}
+
+	return nil
+}
+
+/*
+ExtractMicroformats extracts the top-level microformats2 items of the document,
+including the implied `name`, `photo` and `url` properties.
+
+Nested microformats are available either as property values or as children.
+*/
+func ExtractMicroformats(doc *goquery.Document) []*Microformat {
+	parser := &mfParser{doc: doc}
+	items := []*Microformat{}
+
+	var findRoots func(node *html.Node)
+	findRoots = func(node *html.Node) {
+		if node.Type == html.ElementNode && len(mfRootClasses(node)) > 0 {
+			items = append(items, parser.parse(node))
+			return
+		}
+
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			findRoots(child)
+		}
+	}
+
+	for _, node := range doc.Nodes {
+		findRoots(node)
+	}
+
+	return items
+}
+
+type mfParser struct {
+	doc *goquery.Document
+}
+
+// mfState tracks what was explicitly found while parsing an item, for the implied property rules.
+type mfState struct {
+	mf *Microformat
+
+	hasTextProperty bool // p-* or e-*
+	hasURLProperty  bool // u-*
+	hasNested       bool
+}
+
+func (state *mfState) add(name string, value interface{}) {
+	state.mf.Properties[name] = append(state.mf.Properties[name], value)
+}
+
+func (parser *mfParser) parse(root *html.Node) *Microformat {
+	state := &mfState{mf: &Microformat{
+		Type:       mfRootClasses(root),
+		Properties: make(map[string][]interface{}),
+	}}
+
+	for child := root.FirstChild; child != nil; child = child.NextSibling {
+		parser.parseElement(child, state)
+	}
+
+	properties := state.mf.Properties
+
+	if _, exists := properties["name"]; exists == false && state.hasTextProperty == false && state.hasNested == false {
+		state.add("name", parser.impliedName(root))
+	}
+
+	if _, exists := properties["photo"]; exists == false && state.hasURLProperty == false && state.hasNested == false {
+		if photo := parser.impliedPhoto(root); len(photo) > 0 {
+			state.add("photo", photo)
+		}
+	}
+
+	if _, exists := properties["url"]; exists == false && state.hasURLProperty == false && state.hasNested == false {
+		if implied := parser.impliedURL(root); len(implied) > 0 {
+			state.add("url", implied)
+		}
+	}
+
+	return state.mf
+}
+
+func (parser *mfParser) parseElement(node *html.Node, state *mfState) {
+	if node.Type != html.ElementNode {
+		return
+	}
+
+	properties := mfPropertyClasses(node)
+
+	if len(mfRootClasses(node)) > 0 {
+		nested := parser.parse(node)
+		state.hasNested = true
+
+		if len(properties) == 0 {
+			state.mf.Children = append(state.mf.Children, nested)
+			return
+		}
+
+		for _, property := range properties {
+			switch property.prefix {
+			case "p":
+				nested.Value = firstNonEmptyString(nested.String("name"), parser.textValue(node))
+			case "u":
+				nested.Value = firstNonEmptyString(nested.String("url"), parser.urlValue(node))
+			case "dt":
+				nested.Value = parser.dateTimeValue(node)
+			case "e":
+				nested.Value = strings.TrimSpace(nodeText(node))
+			}
+
+			state.add(property.name, nested)
+		}
+
+		// The nested microformat takes care of its own subtree.
+		return
+	}
+
+	for _, property := range properties {
+		switch property.prefix {
+		case "p":
+			state.hasTextProperty = true
+			state.add(property.name, parser.textValue(node))
+		case "u":
+			state.hasURLProperty = true
+			state.add(property.name, parser.urlValue(node))
+		case "dt":
+			state.add(property.name, parser.dateTimeValue(node))
+		case "e":
+			state.hasTextProperty = true
+			state.add(property.name, &EmbeddedContent{
+				HTML:  strings.TrimSpace(innerHTML(node)),
+				Value: strings.TrimSpace(nodeText(node)),
+			})
+		}
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		parser.parseElement(child, state)
+	}
+}
+
+// textValue parses the value of a `p-*` property.
+func (parser *mfParser) textValue(node *html.Node) string {
+	if value, ok := mfValueClass(node); ok == true {
+		return value
+	}
+
+	switch node.Data {
+	case "abbr", "link":
+		if title, exists := nodeAttr(node, "title"); exists == true {
+			return title
+		}
+	case "data", "input":
+		if value, exists := nodeAttr(node, "value"); exists == true {
+			return value
+		}
+	case "img", "area":
+		if alt, exists := nodeAttr(node, "alt"); exists == true {
+			return alt
+		}
+	}
+
+	return strings.TrimSpace(nodeText(node))
+}
+
+// urlValue parses the value of a `u-*` property.
+func (parser *mfParser) urlValue(node *html.Node) string {
+	attrs := map[string][]string{
+		"a":      {"href"},
+		"area":   {"href"},
+		"link":   {"href"},
+		"img":    {"src"},
+		"audio":  {"src"},
+		"source": {"src"},
+		"iframe": {"src"},
+		"video":  {"src", "poster"},
+		"object": {"data"},
+	}
+
+	for _, attr := range attrs[node.Data] {
+		if value, exists := nodeAttr(node, attr); exists == true {
+			return resolveReference(parser.doc, value)
+		}
+	}
+
+	return resolveReference(parser.doc, parser.textValue(node))
+}
+
+// dateTimeValue parses the value of a `dt-*` property.
+func (parser *mfParser) dateTimeValue(node *html.Node) string {
+	if value, ok := mfValueClass(node); ok == true {
+		return value
+	}
+
+	switch node.Data {
+	case "time", "ins", "del":
+		if dateTime, exists := nodeAttr(node, "datetime"); exists == true {
+			return dateTime
+		}
+	case "abbr":
+		if title, exists := nodeAttr(node, "title"); exists == true {
+			return title
+		}
+	case "data", "input":
+		if value, exists := nodeAttr(node, "value"); exists == true {
+			return value
+		}
+	}
+
+	return strings.TrimSpace(nodeText(node))
+}
+
+func (parser *mfParser) impliedName(root *html.Node) string {
+	nameOf := func(node *html.Node) (string, bool) {
+		switch node.Data {
+		case "img", "area":
+			return nodeAttr(node, "alt")
+		case "abbr":
+			return nodeAttr(node, "title")
+		}
+
+		return "", false
+	}
+
+	if name, ok := nameOf(root); ok == true {
+		return name
+	}
+
+	// Empty alt/title on descendants are ignored, as they are usually decorative.
+	if child := mfOnlyChild(root); child != nil {
+		if name, ok := nameOf(child); ok == true && len(name) > 0 {
+			return name
+		}
+
+		if grandChild := mfOnlyChild(child); grandChild != nil {
+			if name, ok := nameOf(grandChild); ok == true && len(name) > 0 {
+				return name
+			}
+		}
+	}
+
+	return strings.TrimSpace(nodeText(root))
+}
+
+func (parser *mfParser) impliedPhoto(root *html.Node) string {
+	photoOf := func(node *html.Node) (string, bool) {
+		switch node.Data {
+		case "img":
+			return nodeAttr(node, "src")
+		case "object":
+			return nodeAttr(node, "data")
+		}
+
+		return "", false
+	}
+
+	for _, node := range mfImpliedCandidates(root) {
+		if photo, ok := photoOf(node); ok == true {
+			return ResolveURL(parser.doc, photo)
+		}
+	}
+
+	return ""
+}
+
+func (parser *mfParser) impliedURL(root *html.Node) string {
+	for _, node := range mfImpliedCandidates(root) {
+		if node.Data == "a" || node.Data == "area" {
+			if href, exists := nodeAttr(node, "href"); exists == true {
+				return ResolveURL(parser.doc, href)
+			}
+		}
+	}
+
+	return ""
+}
+
+// mfImpliedCandidates returns the root, its only child and its only grandchild (as applicable).
+func mfImpliedCandidates(root *html.Node) []*html.Node {
+	candidates := []*html.Node{root}
+
+	if child := mfOnlyChild(root); child != nil {
+		candidates = append(candidates, child)
+
+		if grandChild := mfOnlyChild(child); grandChild != nil {
+			candidates = append(candidates, grandChild)
+		}
+	}
+
+	return candidates
+}
+
+// mfOnlyChild returns the only child element of the node, provided it is not a microformat root itself.
+func mfOnlyChild(node *html.Node) *html.Node {
+	children := childElements(node)
+
+	if len(children) == 1 && len(mfRootClasses(children[0])) == 0 {
+		return children[0]
+	}
+
+	return nil
+}
+
+/*
+mfValueClass implements the basic value class pattern (http://microformats.org/wiki/value-class-pattern):
+if the element has descendants with the class `value` (or `value-title`), their
+values are concatenated.
+*/
+func mfValueClass(node *html.Node) (string, bool) {
+	values := []string{}
+
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+
+			classes := mfClasses(child)
+			if classes["value-title"] == true {
+				title, _ := nodeAttr(child, "title")
+				values = append(values, title)
+			} else if classes["value"] == true {
+				switch child.Data {
+				case "img", "area":
+					alt, _ := nodeAttr(child, "alt")
+					values = append(values, alt)
+				case "data":
+					value, _ := nodeAttr(child, "value")
+					values = append(values, value)
+				case "abbr":
+					title, _ := nodeAttr(child, "title")
+					values = append(values, title)
+				default:
+					values = append(values, strings.TrimSpace(nodeText(child)))
+				}
+			} else if len(mfRootClasses(child)) == 0 {
+				walk(child)
+			}
+		}
+	}
+	walk(node)
+
+	if len(values) == 0 {
+		return "", false
+	}
+
+	return strings.Join(values, ""), true
+}
+
+type mfProperty struct {
+	prefix string
+	name   string
+}
+
+func mfClasses(node *html.Node) map[string]bool {
+	classes := make(map[string]bool)
+
+	class, _ := nodeAttr(node, "class")
+	for _, name := range strings.Fields(class) {
+		classes[name] = true
+	}
+
+	return classes
+}
+
+// mfRootClasses returns the sorted `h-*` classes of the node.
+func mfRootClasses(node *html.Node) []string {
+	roots := []string{}
+
+	for class := range mfClasses(node) {
+		if strings.HasPrefix(class, "h-") && mfValidName(class[2:]) {
+			roots = append(roots, class)
+		}
+	}
+	sort.Strings(roots)
+
+	return roots
+}
+
+// mfPropertyClasses returns the `p-*`, `u-*`, `dt-*` and `e-*` classes of the node.
+func mfPropertyClasses(node *html.Node) []mfProperty {
+	properties := []mfProperty{}
+
+	class, _ := nodeAttr(node, "class")
+	for _, name := range strings.Fields(class) {
+		for _, prefix := range []string{"p", "u", "dt", "e"} {
+			if strings.HasPrefix(name, prefix+"-") && mfValidName(name[len(prefix)+1:]) {
+				properties = append(properties, mfProperty{prefix, name[len(prefix)+1:]})
+			}
+		}
+	}
+
+	return properties
+}
+
+// mfValidName checks that the name is made of lowercase letters and dashes.
+func mfValidName(name string) bool {
+	if len(name) == 0 {
+		return false
+	}
+
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && r != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func innerHTML(node *html.Node) string {
+	var buffer bytes.Buffer
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		html.Render(&buffer, child)
+	}
+
+	return buffer.String()
+}
+
+func firstNonEmptyString(values ...string) string {
+	for _, value := range values {
+		if len(value) > 0 {
+			return value
+		}
+	}
+
+	return ""
+}
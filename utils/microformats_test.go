@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExtractMicroformats(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			"implied properties",
+			`<a class="h-card" href="/me"><img src="/me.jpg" alt="Someone"></a>`,
+			`[{"type":["h-card"],"properties":{"name":["Someone"],"photo":["https://example.com/me.jpg"],"url":["https://example.com/me"]}}]`,
+		},
+		{
+			"h-entry",
+			`<article class="h-entry">
+				<h1 class="p-name">A Post</h1>
+				<a class="u-url" href="/posts/1">Permalink</a>
+				<time class="dt-published" datetime="2015-03-01T15:04:05Z">1 March</time>
+				<a class="p-author h-card" href="/me">Someone</a>
+				<span class="p-category">one</span><span class="p-category">two</span>
+				<div class="e-content"><p>Some <b>text</b></p></div>
+			</article>`,
+			`[{"type":["h-entry"],"properties":{` +
+				`"author":[{"type":["h-card"],"properties":{"name":["Someone"],"url":["https://example.com/me"]},"value":"Someone"}],` +
+				`"category":["one","two"],` +
+				`"content":[{"html":"\u003cp\u003eSome \u003cb\u003etext\u003c/b\u003e\u003c/p\u003e","value":"Some text"}],` +
+				`"name":["A Post"],"published":["2015-03-01T15:04:05Z"],"url":["https://example.com/posts/1"]}}]`,
+		},
+		{
+			"value class pattern",
+			`<div class="h-event"><span class="p-name">Event</span>
+				<span class="p-tel"><span class="value">+1</span> (<span class="value">555</span>) <span class="value">1234</span></span>
+				<abbr class="p-location" title="Somewhere">SW</abbr>
+			</div>`,
+			`[{"type":["h-event"],"properties":{"location":["Somewhere"],"name":["Event"],"tel":["+15551234"]}}]`,
+		},
+		{
+			"children",
+			`<div class="h-feed"><p class="p-name">Feed</p><div class="h-entry"><p class="p-name">Entry</p></div></div>`,
+			`[{"type":["h-feed"],"properties":{"name":["Feed"]},"children":[{"type":["h-entry"],"properties":{"name":["Entry"]}}]}]`,
+		},
+		{
+			"invalid class names",
+			`<div class="h-Entry h-"><p class="p-Name">Not a microformat</p></div>`,
+			`[]`,
+		},
+	}
+
+	for _, test := range tests {
+		doc := newTestDocument(t, "https://example.com/", "<html><body>"+test.body+"</body></html>")
+
+		if encoded, err := json.Marshal(ExtractMicroformats(doc)); err != nil || string(encoded) != test.want {
+			t.Errorf("%s: ExtractMicroformats() = %s, want %s", test.name, encoded, test.want)
+		}
+	}
+}
+
+func TestMicroformatString(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/", `<html><body><div class="h-entry">
+		<a class="p-author h-card" href="/me">Someone</a>
+		<div class="e-content"> Some <i>text</i> </div>
+	</div></body></html>`)
+
+	entry := ExtractMicroformats(doc)[0]
+
+	tests := map[string]string{"author": "Someone", "content": "Some text", "missing": ""}
+	for name, want := range tests {
+		if value := entry.String(name); value != want {
+			t.Errorf("String(%q) = %q, want %q", name, value, want)
+		}
+	}
+
+	if card := entry.Microformat("author"); card == nil || card.Is("h-card") == false {
+		t.Errorf("Microformat(%q) = %+v, want the h-card", "author", card)
+	}
+}
@@ -0,0 +1,278 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+/*
+Money is a price, or a price range, in a currency. The amounts are decimal strings
+(eg: "1234.56") - as written, with `.` as the decimal separator and no thousands
+separators - so that they are exact and keep their decimal places.
+*/
+type Money struct {
+	Amount    string `json:"amount"`
+	MaxAmount string `json:"maxAmount,omitempty"` // Upper bound, for price ranges
+	Currency  string `json:"currency,omitempty"`  // 3-letter ISO 4217 code
+}
+
+// Currency symbols (and the common abbreviations) mapped to the ISO 4217 codes. `$` is taken to be USD.
+var currencySymbols = map[string]string{
+	"US$": "USD", "$": "USD", "C$": "CAD", "CA$": "CAD", "A$": "AUD", "AU$": "AUD", "NZ$": "NZD",
+	"HK$": "HKD", "S$": "SGD", "R$": "BRL", "MX$": "MXN", "NT$": "TWD",
+	"€": "EUR", "£": "GBP", "¥": "JPY", "JP¥": "JPY", "CN¥": "CNY", "元": "CNY", "₹": "INR", "Rs.": "INR", "Rs": "INR",
+	"₩": "KRW", "₽": "RUB", "₺": "TRY", "₪": "ILS", "₫": "VND", "₱": "PHP", "฿": "THB", "₴": "UAH",
+	"₦": "NGN", "zł": "PLN", "Kč": "CZK", "Ft": "HUF", "lei": "RON", "R": "ZAR",
+}
+
+// ISO 4217 codes of the commonly used currencies.
+var currencyCodes = map[string]bool{
+	"USD": true, "EUR": true, "GBP": true, "JPY": true, "CNY": true, "INR": true, "CAD": true, "AUD": true,
+	"NZD": true, "CHF": true, "SEK": true, "NOK": true, "DKK": true, "PLN": true, "CZK": true, "HUF": true,
+	"RON": true, "BGN": true, "RUB": true, "UAH": true, "TRY": true, "ILS": true, "AED": true, "SAR": true,
+	"ZAR": true, "NGN": true, "EGP": true, "KES": true, "BRL": true, "MXN": true, "ARS": true, "CLP": true,
+	"COP": true, "PEN": true, "KRW": true, "TWD": true, "HKD": true, "SGD": true, "MYR": true, "THB": true,
+	"IDR": true, "PHP": true, "VND": true, "PKR": true, "BDT": true, "ISK": true, "KWD": true, "BHD": true,
+	"OMR": true, "JOD": true, "TND": true, "QAR": true,
+}
+
+var (
+	currencyCode = regexp.MustCompile(`\b[A-Z]{3}\b`)
+	priceNumber  = regexp.MustCompile(`\d[\d.,'\s\x{00a0}\x{202f}]*`)
+	priceRange   = regexp.MustCompile(`\d\s*(?:[-–—]|\bto\b)\s*\D{0,4}\d`)
+	digits       = regexp.MustCompile(`^\d+$`)
+)
+
+// The currency symbols, longest first - so that `US$` is matched before `$`.
+var currencySymbolsByLength = sortedCurrencySymbols()
+
+/*
+ParsePrice parses a price as written on the web, eg: `$1,234.56`, `1.234,56 €`,
+`EUR 12`, `1 234,56 zł` or a range like `$10–$20`, guessing the locale specific
+decimal and thousands separators (see parseAmount).
+
+The currency is taken from the given currency (eg: from a `priceCurrency` tag) if
+it is a valid code, or detected from the ISO 4217 code or currency symbol in the
+value. The currency is left empty if it can't be determined - eg: for symbols shared
+by several currencies, like `kr`.
+*/
+func ParsePrice(value string, currency string) (*Money, error) {
+	return parsePrice(value, currency, parseAmount)
+}
+
+/*
+ParseDecimalPrice parses a machine-readable price, eg: the schema.org `price` or the
+`product:price:amount` meta tag, which always uses `.` as the decimal separator (eg:
+`2.995` is 2.995, not 2995). Commas and spaces are taken as thousands separators -
+unless a comma comes after the last `.` (eg: `12,99` or `1.299,00`, which some pages use
+regardless), in which case the separators are detected as with ParsePrice. The
+currency is as with ParsePrice.
+*/
+func ParseDecimalPrice(value string, currency string) (*Money, error) {
+	return parsePrice(value, currency, parseDecimal)
+}
+
+func parsePrice(value string, currency string, parse func(string) (string, error)) (*Money, error) {
+	money := new(Money)
+
+	numbers := priceNumber.FindAllString(value, -1)
+	if len(numbers) == 0 {
+		return nil, errors.New(fmt.Sprint("No price found: ", value))
+	}
+
+	amount, err := parse(numbers[0])
+	if err != nil {
+		return nil, err
+	}
+	money.Amount = amount
+
+	if len(numbers) > 1 && priceRange.MatchString(value) {
+		if maxAmount, err := parse(numbers[1]); err == nil && decimalLess(amount, maxAmount) {
+			money.MaxAmount = maxAmount
+		}
+	}
+
+	money.Currency = NormalizeCurrency(currency)
+	if len(money.Currency) == 0 {
+		money.Currency = detectCurrency(value)
+	}
+
+	return money, nil
+}
+
+// NormalizeCurrency returns the ISO 4217 code for a currency code or symbol, or an empty string if it is unknown.
+func NormalizeCurrency(currency string) string {
+	currency = strings.TrimSpace(currency)
+
+	if code := strings.ToUpper(currency); currencyCodes[code] == true {
+		return code
+	}
+
+	return currencySymbols[currency]
+}
+
+// detectCurrency detects the currency of a price from the ISO 4217 code or the currency symbol in it.
+func detectCurrency(value string) string {
+	for _, code := range currencyCode.FindAllString(value, -1) {
+		if currencyCodes[code] == true {
+			return code
+		}
+	}
+
+	for _, symbol := range currencySymbolsByLength {
+		index := strings.Index(value, symbol)
+		if index < 0 {
+			continue
+		}
+
+		// Symbols made of letters (eg: `R`, `Ft`) must not be part of a word.
+		if isLetter(symbol[0]) {
+			before := index == 0 || isLetter(value[index-1]) == false
+			after := index+len(symbol) == len(value) || isLetter(value[index+len(symbol)]) == false
+			if before == false || after == false {
+				continue
+			}
+		}
+
+		return currencySymbols[symbol]
+	}
+
+	return ""
+}
+
+/*
+parseAmount parses a number with locale specific separators into a decimal string.
+If both `.` and `,` are used, the last one is the decimal separator. If only one of
+them is used, it is the thousands separator if it is repeated or followed by exactly
+3 digits (eg: `1.234`, `1,234,567`), and the decimal separator otherwise. Spaces and
+apostrophes are thousands separators.
+*/
+func parseAmount(number string) (string, error) {
+	number = strings.NewReplacer(" ", "", "'", "", "\u00a0", "", "\u202f", "", "\t", "", "\n", "").Replace(number)
+	number = strings.TrimRight(number, ".,")
+
+	lastDot, lastComma := strings.LastIndex(number, "."), strings.LastIndex(number, ",")
+
+	decimal := ""
+	switch {
+	case lastDot >= 0 && lastComma >= 0:
+		decimal = "."
+		if lastComma > lastDot {
+			decimal = ","
+		}
+
+	case lastDot >= 0 || lastComma >= 0:
+		separator := "."
+		if lastComma >= 0 {
+			separator = ","
+		}
+
+		last := strings.LastIndex(number, separator)
+		thousands := strings.Count(number, separator) > 1 ||
+			(len(number)-last-1 == 3 && strings.Trim(number[:last], "0") != "")
+		if thousands == false {
+			decimal = separator
+		}
+	}
+
+	integer, fraction := number, ""
+	if len(decimal) > 0 {
+		index := strings.LastIndex(number, decimal)
+		integer, fraction = number[:index], number[index+1:]
+	}
+
+	return decimalString(strings.NewReplacer(",", "", ".", "").Replace(integer), fraction)
+}
+
+/*
+parseDecimal parses a number with `.` as the decimal separator (and `,`, spaces, etc as
+thousands separators) into a decimal string. A number with a comma after the last `.`
+is not written that way, and is parsed with parseAmount instead.
+*/
+func parseDecimal(number string) (string, error) {
+	if strings.LastIndex(number, ",") > strings.LastIndex(number, ".") {
+		return parseAmount(number)
+	}
+
+	number = strings.NewReplacer(",", "", " ", "", "'", "", "\u00a0", "", "\u202f", "", "\t", "", "\n", "").Replace(number)
+	number = strings.TrimRight(number, ".")
+
+	integer, fraction := number, ""
+	if index := strings.Index(number, "."); index >= 0 {
+		integer, fraction = number[:index], number[index+1:]
+	}
+
+	return decimalString(integer, fraction)
+}
+
+// decimalString joins the integer and fractional digits into a decimal string, eg: "1234.56", without the leading zeros.
+func decimalString(integer string, fraction string) (string, error) {
+	integer = strings.TrimLeft(integer, "0")
+	if len(integer) == 0 {
+		integer = "0"
+	}
+
+	if digits.MatchString(integer) == false || (len(fraction) > 0 && digits.MatchString(fraction) == false) {
+		return "", errors.New(fmt.Sprint("Invalid amount: ", integer, ".", fraction))
+	}
+
+	if len(fraction) > 0 {
+		return integer + "." + fraction, nil
+	}
+
+	return integer, nil
+}
+
+// decimalLess compares two decimal strings (as returned by decimalString).
+func decimalLess(a string, b string) bool {
+	aInteger, aFraction := splitDecimal(a)
+	bInteger, bFraction := splitDecimal(b)
+
+	if len(aInteger) != len(bInteger) {
+		return len(aInteger) < len(bInteger)
+	}
+	if aInteger != bInteger {
+		return aInteger < bInteger
+	}
+
+	// Pad the fractions to the same length, eg: "5" and "25" => "50" and "25".
+	for len(aFraction) < len(bFraction) {
+		aFraction += "0"
+	}
+	for len(bFraction) < len(aFraction) {
+		bFraction += "0"
+	}
+
+	return aFraction < bFraction
+}
+
+func splitDecimal(decimal string) (string, string) {
+	if index := strings.Index(decimal, "."); index >= 0 {
+		return decimal[:index], decimal[index+1:]
+	}
+
+	return decimal, ""
+}
+
+func isLetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
+
+func sortedCurrencySymbols() []string {
+	symbols := []string{}
+	for symbol := range currencySymbols {
+		symbols = append(symbols, symbol)
+	}
+
+	sort.Slice(symbols, func(i, j int) bool {
+		if len(symbols[i]) != len(symbols[j]) {
+			return len(symbols[i]) > len(symbols[j])
+		}
+		return symbols[i] < symbols[j]
+	})
+
+	return symbols
+}
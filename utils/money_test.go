@@ -0,0 +1,77 @@
+package utils
+
+import "testing"
+
+func TestParsePrice(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		want     *Money
+	}{
+		{"$1,234.56", "", &Money{Amount: "1234.56", Currency: "USD"}},
+		{"1.234,56 €", "", &Money{Amount: "1234.56", Currency: "EUR"}},
+		{"EUR 12", "", &Money{Amount: "12", Currency: "EUR"}},
+		{"1 234,56 zł", "", &Money{Amount: "1234.56", Currency: "PLN"}},
+		{"CHF 1'234.50", "", &Money{Amount: "1234.50", Currency: "CHF"}},
+		{"£0.99", "", &Money{Amount: "0.99", Currency: "GBP"}},
+		{"1.234", "", &Money{Amount: "1234"}},
+		{"12,5", "", &Money{Amount: "12.5"}},
+		{"$10–$20", "", &Money{Amount: "10", MaxAmount: "20", Currency: "USD"}},
+		{"10 to 20 EUR", "", &Money{Amount: "10", MaxAmount: "20", Currency: "EUR"}},
+		{"$20 - $10", "", &Money{Amount: "20", Currency: "USD"}},
+		{"100 kr", "", &Money{Amount: "100"}},
+		{"100 kr", "sek", &Money{Amount: "100", Currency: "SEK"}},
+		{"19.99", "€", &Money{Amount: "19.99", Currency: "EUR"}},
+		{"R 250", "", &Money{Amount: "250", Currency: "ZAR"}},
+		{"Free", "", nil},
+	}
+
+	for _, test := range tests {
+		checkMoney(t, "ParsePrice", ParsePrice, test.value, test.currency, test.want)
+	}
+}
+
+func TestParseDecimalPrice(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency string
+		want     *Money
+	}{
+		{"2.995", "KWD", &Money{Amount: "2.995", Currency: "KWD"}},
+		{"1234.5", "USD", &Money{Amount: "1234.5", Currency: "USD"}},
+		{"1,234.56", "", &Money{Amount: "1234.56"}},
+		{"012.00", "", &Money{Amount: "12.00"}},
+		{"0.5", "", &Money{Amount: "0.5"}},
+		{"10.5 - 20.25", "EUR", &Money{Amount: "10.5", MaxAmount: "20.25", Currency: "EUR"}},
+		{"10.5 - 10.25", "EUR", &Money{Amount: "10.5", Currency: "EUR"}},
+		{"12,99", "EUR", &Money{Amount: "12.99", Currency: "EUR"}},
+		{"1.299,00", "", &Money{Amount: "1299.00"}},
+		{"19,5", "", &Money{Amount: "19.5"}},
+		{"1,234", "", &Money{Amount: "1234"}},
+		{"1,234,567", "", &Money{Amount: "1234567"}},
+		{"", "USD", nil},
+	}
+
+	for _, test := range tests {
+		checkMoney(t, "ParseDecimalPrice", ParseDecimalPrice, test.value, test.currency, test.want)
+	}
+}
+
+// checkMoney checks the result of the parse function (named `name` in the errors) for the value and currency.
+func checkMoney(t *testing.T, name string, parse func(string, string) (*Money, error), value string, currency string, want *Money) {
+	t.Helper()
+
+	money, err := parse(value, currency)
+	if want == nil {
+		if err == nil {
+			t.Errorf("%s(%q, %q) = %+v, want an error", name, value, currency, money)
+		}
+		return
+	}
+
+	if err != nil {
+		t.Errorf("%s(%q, %q) returned an error: %v", name, value, currency, err)
+	} else if *money != *want {
+		t.Errorf("%s(%q, %q) = %+v, want %+v", name, value, currency, *money, *want)
+	}
+}
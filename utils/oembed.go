@@ -0,0 +1,224 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// OEmbed is an oEmbed (https://oembed.com) response.
+type OEmbed struct {
+	Type            string `json:"type"` // photo, video, rich or link
+	Title           string `json:"title,omitempty"`
+	AuthorName      string `json:"authorName,omitempty"`
+	AuthorURL       string `json:"authorUrl,omitempty"`
+	ProviderName    string `json:"providerName,omitempty"`
+	ProviderURL     string `json:"providerUrl,omitempty"`
+	ThumbnailURL    string `json:"thumbnailUrl,omitempty"`
+	ThumbnailWidth  int    `json:"thumbnailWidth,omitempty"`
+	ThumbnailHeight int    `json:"thumbnailHeight,omitempty"`
+	URL             string `json:"url,omitempty"`  // The image, for photos
+	HTML            string `json:"html,omitempty"` // The embed HTML, for videos and rich content
+	Width           int    `json:"width,omitempty"`
+	Height          int    `json:"height,omitempty"`
+}
+
+// OEmbedProvider is an oEmbed provider, with the URL schemes it supports - a `*` matches within a host label or path segment.
+type OEmbedProvider struct {
+	Name     string
+	Schemes  []string
+	Endpoint string // May contain a `{format}` placeholder
+}
+
+/*
+FindOEmbedProvider finds the registered provider for the URL (see OEmbedProviders).
+The schemes match both `http` and `https` URLs. Returns nil if there is none.
+*/
+func FindOEmbedProvider(pageURL string) *OEmbedProvider {
+	normalized := httpsURL(pageURL)
+
+	for i, provider := range OEmbedProviders {
+		for _, scheme := range provider.Schemes {
+			if wildcardMatch(httpsURL(scheme), normalized) {
+				return &OEmbedProviders[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// OEmbedURL returns the endpoint URL of the provider for fetching the oEmbed (JSON) response of the page.
+func (provider *OEmbedProvider) OEmbedURL(pageURL string) string {
+	endpoint, err := url.Parse(strings.Replace(provider.Endpoint, "{format}", "json", -1))
+	if err != nil {
+		return ""
+	}
+
+	params := endpoint.Query()
+	params.Set("url", pageURL)
+	params.Set("format", "json")
+	endpoint.RawQuery = params.Encode()
+
+	return endpoint.String()
+}
+
+/*
+DiscoverOEmbedURL returns the oEmbed URL advertised by the page using
+`<link rel="alternate" type="application/json+oembed">`, or the XML variant if
+there is no JSON one. Returns an empty string if there is none.
+*/
+func DiscoverOEmbedURL(doc *goquery.Document) string {
+	for _, linkType := range []string{"application/json+oembed", "text/xml+oembed", "application/xml+oembed"} {
+		if href, exists := doc.Find("link[rel~='alternate'][type='" + linkType + "']").First().Attr("href"); exists == true {
+			if oembedURL := ResolveURL(doc, href); len(oembedURL) > 0 {
+				return oembedURL
+			}
+		}
+	}
+
+	return ""
+}
+
+// FetchOEmbed fetches and parses the (JSON or XML) oEmbed response at the URL.
+func FetchOEmbed(oembedURL string) (*OEmbed, error) {
+	body, err := fetch(oembedURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseOEmbed(body)
+}
+
+// ParseOEmbed parses a JSON or XML oEmbed response.
+func ParseOEmbed(body []byte) (*OEmbed, error) {
+	raw := new(oembedResponse)
+	body = bytes.TrimSpace(body)
+
+	var err error
+	if bytes.HasPrefix(body, []byte("<")) {
+		err = xml.Unmarshal(body, raw)
+	} else {
+		err = json.Unmarshal(body, raw)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	oembedType := strings.ToLower(strings.TrimSpace(raw.Type))
+	if len(oembedType) == 0 {
+		return nil, errors.New("Invalid oEmbed response: no type.")
+	}
+
+	return &OEmbed{
+		Type:            oembedType,
+		Title:           strings.TrimSpace(raw.Title),
+		AuthorName:      strings.TrimSpace(raw.AuthorName),
+		AuthorURL:       HTTPURL(raw.AuthorURL),
+		ProviderName:    strings.TrimSpace(raw.ProviderName),
+		ProviderURL:     HTTPURL(raw.ProviderURL),
+		ThumbnailURL:    HTTPURL(raw.ThumbnailURL),
+		ThumbnailWidth:  int(raw.ThumbnailWidth),
+		ThumbnailHeight: int(raw.ThumbnailHeight),
+		URL:             HTTPURL(raw.URL),
+		HTML:            strings.TrimSpace(raw.HTML),
+		Width:           int(raw.Width),
+		Height:          int(raw.Height),
+	}, nil
+}
+
+// oembedResponse is the oEmbed response as sent by the providers.
+type oembedResponse struct {
+	Type            string          `json:"type" xml:"type"`
+	Title           string          `json:"title" xml:"title"`
+	AuthorName      string          `json:"author_name" xml:"author_name"`
+	AuthorURL       string          `json:"author_url" xml:"author_url"`
+	ProviderName    string          `json:"provider_name" xml:"provider_name"`
+	ProviderURL     string          `json:"provider_url" xml:"provider_url"`
+	ThumbnailURL    string          `json:"thumbnail_url" xml:"thumbnail_url"`
+	ThumbnailWidth  oembedDimension `json:"thumbnail_width" xml:"thumbnail_width"`
+	ThumbnailHeight oembedDimension `json:"thumbnail_height" xml:"thumbnail_height"`
+	URL             string          `json:"url" xml:"url"`
+	HTML            string          `json:"html" xml:"html"`
+	Width           oembedDimension `json:"width" xml:"width"`
+	Height          oembedDimension `json:"height" xml:"height"`
+}
+
+// oembedDimension is a width/height, which some providers send as a string (or null, or "100%").
+type oembedDimension int
+
+func (d *oembedDimension) UnmarshalJSON(data []byte) error {
+	return d.UnmarshalText(bytes.Trim(data, `"`))
+}
+
+func (d *oembedDimension) UnmarshalText(text []byte) error {
+	*d = oembedDimension(parseDimension(string(text)))
+	return nil
+}
+
+/*
+wildcardMatch matches the URL against a scheme in which `*` matches any characters
+within a single host label or path segment, eg: `https://*.example.com/videos/*`
+matches `https://www.example.com/videos/1` but not `https://example.org/x.example.com/videos/1`.
+*/
+func wildcardMatch(pattern string, value string) bool {
+	patternHost, patternPath := splitScheme(pattern)
+	host, path := splitScheme(value)
+
+	return segmentMatch(patternHost, host, ".:@") && segmentMatch(patternPath, path, "/")
+}
+
+// segmentMatch matches the value against the pattern, where `*` matches any characters except the separators.
+func segmentMatch(pattern string, value string, separators string) bool {
+	star := strings.Index(pattern, "*")
+	if star < 0 {
+		return pattern == value
+	}
+
+	if strings.HasPrefix(value, pattern[:star]) == false {
+		return false
+	}
+	value = value[star:]
+
+	limit := strings.IndexAny(value, separators)
+	if limit < 0 {
+		limit = len(value)
+	}
+
+	for i := 0; i <= limit; i++ {
+		if segmentMatch(pattern[star+1:], value[i:], separators) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitScheme splits a URL (or URL scheme) into the part up to the host, eg: "https://www.example.com", and the rest.
+func splitScheme(value string) (string, string) {
+	index := strings.Index(value, "://")
+	if index < 0 {
+		// eg: "spotify:*"
+		return "", value
+	}
+
+	if end := strings.IndexAny(value[index+3:], "/?#"); end >= 0 {
+		return value[:index+3+end], value[index+3+end:]
+	}
+
+	return value, ""
+}
+
+// httpsURL replaces the `http` scheme of the URL with `https`.
+func httpsURL(value string) string {
+	if strings.HasPrefix(value, "http://") {
+		return "https://" + value[len("http://"):]
+	}
+
+	return value
+}
@@ -0,0 +1,265 @@
+package utils
+
+/*
+OEmbedProviders is the registry of the known oEmbed providers, from
+https://oembed.com/providers.json - one entry per endpoint of a provider. Left out are
+the endpoints without URL schemes (those providers are found by discovery) and the ones
+whose endpoint URL has a wildcard (eg: per-customer subdomains).
+
+Some of the providers require an API key (eg: Facebook and Instagram) - their oEmbed
+requests fail without it, and the page is scraped as usual. More providers can be added
+by appending to it.
+*/
+var OEmbedProviders = []OEmbedProvider{
+	{"23HQ", []string{"http://www.23hq.com/*/photo/*"}, "http://www.23hq.com/23/oembed"},
+	{"Abraia", []string{"https://store.abraia.me/*"}, "https://api.abraia.me/oembed"},
+	{"Adways", []string{"http://play.adpaths.com/experience/*"}, "http://www.adways.com/oembed"},
+	{"Alpha App Net", []string{"https://alpha.app.net/*/post/*", "https://photos.app.net/*/*"}, "https://alpha-api.app.net/oembed"},
+	{"Altru", []string{"https://app.altrulabs.com/*/*?answer_id=*", "https://app.altrulabs.com/player/*"}, "https://api.altrulabs.com/api/v1/social/oembed"},
+	{"amCharts Live Editor", []string{"http://live.amcharts.com/*", "https://live.amcharts.com/*"}, "https://live.amcharts.com/oembed"},
+	{"Amtraker", []string{"https://amtraker.com/trains/*", "https://beta.amtraker.com/trains/*"}, "https://api.amtraker.com/v3/oembed"},
+	{"Animatron", []string{"https://www.animatron.com/project/*", "https://animatron.com/project/*"}, "https://animatron.com/oembed/json"},
+	{"Animoto", []string{"http://animoto.com/play/*"}, "http://animoto.com/oembeds/create"},
+	{"Apester", []string{"https://renderer.apester.com/v2/*?preview=true&iframe_preview=true"}, "https://display.apester.com/oembed"},
+	{"ArcGIS StoryMaps", []string{"https://storymaps.arcgis.com/stories/*"}, "https://storymaps.arcgis.com/oembed"},
+	{"Audioboom", []string{"https://audioboom.com/channels/*", "https://audioboom.com/channel/*", "https://audioboom.com/playlists/*", "https://audioboom.com/podcasts/*", "https://audioboom.com/podcast/*", "https://audioboom.com/posts/*", "https://audioboom.com/episodes/*"}, "https://audioboom.com/publishing/oembed/v4.{format}"},
+	{"Audioclip", []string{"https://audioclip.naver.com/channels/*/clips/*", "https://audioclip.naver.com/audiobooks/*"}, "https://audioclip.naver.com/oembed"},
+	{"Audiomack", []string{"https://audiomack.com/*/song/*", "https://audiomack.com/*/album/*", "https://audiomack.com/*/playlist/*"}, "https://audiomack.com/oembed"},
+	{"Audiomeans", []string{"https://podcasts.audiomeans.fr/*"}, "https://podcasts.audiomeans.fr/services/oembed"},
+	{"Avocode", []string{"https://app.avocode.com/view/*"}, "https://stage-embed.avocode.com/api/oembed"},
+	{"AxiomNinja", []string{"http://axiom.ninja/*"}, "http://axiom.ninja/oembed/"},
+	{"Backtracks", []string{"http://backtracks.fm/*/*/e/*", "https://backtracks.fm/*/*/e/*"}, "https://backtracks.fm/oembed"},
+	{"Beautiful.AI", []string{"https://www.beautiful.ai/*"}, "https://www.beautiful.ai/api/oembed"},
+	{"Blackfire.io", []string{"https://blackfire.io/profiles/*/graph", "https://blackfire.io/profiles/compare/*/graph"}, "https://blackfire.io/oembed"},
+	{"Blogcast", []string{"https://blogcast.host/embed/*", "https://blogcast.host/embedly/*"}, "https://blogcast.host/oembed"},
+	{"Bluesky Social", []string{"https://bsky.app/profile/*/post/*"}, "https://embed.bsky.app/oembed"},
+	{"Box Office Buz", []string{"http://boxofficebuz.com/video/*"}, "http://boxofficebuz.com/oembed"},
+	{"BrioVR", []string{"https://view.briovr.com/api/v1/worlds/oembed/*"}, "https://view.briovr.com/api/v1/worlds/oembed/"},
+	{"Buttondown", []string{"https://buttondown.email/*"}, "https://buttondown.email/embed"},
+	{"Byzart Project", []string{"https://cmc.byzart.eu/files/*"}, "https://cmc.byzart.eu/oembed/"},
+	{"Cacoo", []string{"https://cacoo.com/diagrams/*"}, "http://cacoo.com/oembed.{format}"},
+	{"Canva", []string{"https://www.canva.com/design/*/view"}, "https://www.canva.com/_oembed"},
+	{"Cardinal Blue", []string{"https://notes.cardinalblue.com/*"}, "https://notes.cardinalblue.com/oembed"},
+	{"CatBoat", []string{"http://img.catbo.at/*"}, "http://img.catbo.at/oembed.json"},
+	{"Ceros", []string{"http://view.ceros.com/*", "https://view.ceros.com/*"}, "http://view.ceros.com/oembed"},
+	{"ChartBlocks", []string{"http://public.chartblocks.com/c/*"}, "http://embed.chartblocks.com/1.0/oembed"},
+	{"chirbit.com", []string{"http://chirb.it/*"}, "http://chirb.it/oembed.{format}"},
+	{"CircuitLab", []string{"https://www.circuitlab.com/circuit/*"}, "https://www.circuitlab.com/circuit/oembed/"},
+	{"Clipland", []string{"http://www.clipland.com/v/*", "https://www.clipland.com/v/*"}, "https://www.clipland.com/api/oembed"},
+	{"Cloudup", []string{"https://cloudup.com/*"}, "https://cloudup.com/oembed"},
+	{"Clyp", []string{"http://clyp.it/*", "http://clyp.it/playlist/*"}, "http://api.clyp.it/oembed/"},
+	{"CodePen", []string{"http://codepen.io/*", "https://codepen.io/*", "http://codepen.io/*/pen/*", "https://codepen.io/*/pen/*"}, "https://codepen.io/api/oembed"},
+	{"Codepoints", []string{"http://codepoints.net/*", "https://codepoints.net/*", "http://www.codepoints.net/*", "https://www.codepoints.net/*"}, "https://codepoints.net/api/v1/oembed"},
+	{"CodeSandbox", []string{"https://codesandbox.io/s/*", "https://codesandbox.io/embed/*"}, "https://codesandbox.io/oembed"},
+	{"Commaful", []string{"https://commaful.com/play/*"}, "https://commaful.com/api/oembed/"},
+	{"Coub", []string{"http://coub.com/view/*", "http://coub.com/embed/*"}, "http://coub.com/api/oembed.{format}"},
+	{"Crowd Ranking", []string{"http://crowdranking.com/*/*"}, "http://crowdranking.com/api/oembed.{format}"},
+	{"Crowdsignal", []string{"https://*.crowdsignal.com/*", "https://*.crowdsignal.net/*", "https://poll.fm/*", "https://survey.fm/*"}, "https://api.crowdsignal.com/oembed"},
+	{"Crumb.sh", []string{"https://crumb.sh/*"}, "https://crumb.sh/oembed/"},
+	{"Cueup DJ Booking", []string{"https://www.cueup.io/artist/*"}, "https://www.cueup.io/proxy/oembed.{format}"},
+	{"Curated", []string{"https://*.curated.co/*"}, "https://api.curated.co/oembed"},
+	{"CustomerDB", []string{"http://app.customerdb.com/share/*"}, "https://app.customerdb.com/api/oembed"},
+	{"dadan", []string{"https://app.dadan.io/*"}, "https://app.dadan.io/api/video/oembed"},
+	{"Dailymotion", []string{"https://www.dailymotion.com/video/*", "https://dai.ly/*"}, "https://www.dailymotion.com/services/oembed"},
+	{"DALEXNI", []string{"https://dalexni.com/i/*"}, "https://dalexni.com/oembed/"},
+	{"Datawrapper", []string{"https://datawrapper.dwcdn.net/*"}, "https://api.datawrapper.de/v3/oembed/"},
+	{"Deseret News", []string{"https://*.deseret.com/*"}, "https://embed.deseret.com/"},
+	{"Descript", []string{"https://share.descript.com/view/*"}, "https://api.descript.com/v2/oembed"},
+	{"Deviantart.com", []string{"http://*.deviantart.com/art/*", "http://*.deviantart.com/*#/d*", "http://fav.me/*", "http://sta.sh/*", "https://*.deviantart.com/art/*", "https://*.deviantart.com/*/art/*", "https://sta.sh/*", "https://*.deviantart.com/*#/d*"}, "http://backend.deviantart.com/oembed"},
+	{"Digiteka", []string{"https://www.ultimedia.com/central/video/edit/id/*/topic_id/*/", "https://www.ultimedia.com/default/index/videogeneric/id/*/showtitle/1/viewnc/1", "https://www.ultimedia.com/default/index/videogeneric/id/*", "https://www.ultimedia.com/default/index/videomusic/id/*/showtitle/1/viewnc/1", "https://www.ultimedia.com/default/index/videomusic/id/*"}, "https://www.ultimedia.com/api/search/oembed"},
+	{"Docdroid", []string{"https://*.docdroid.net/*", "http://*.docdroid.net/*", "https://docdro.id/*", "http://docdro.id/*"}, "https://www.docdroid.net/api/oembed"},
+	{"Docswell", []string{"https://www.docswell.com/s/*/*", "https://docswell.com/s/*/*"}, "https://www.docswell.com/service/oembed"},
+	{"Dotsub", []string{"http://dotsub.com/view/*"}, "http://dotsub.com/services/oembed"},
+	{"Dreambroker", []string{"https://www.dreambroker.com/channel/*"}, "https://dreambroker.com/channel/oembed"},
+	{"DTube", []string{"https://d.tube/v/*"}, "https://api.d.tube/oembed"},
+	{"EduMedia", []string{"https://www.edumedia-sciences.com/*/media/*"}, "https://www.edumedia-sciences.com/oembed.json"},
+	{"EgliseInfo", []string{"http://egliseinfo.catholique.fr/*"}, "http://egliseinfo.catholique.fr/api/oembed"},
+	{"Embedery", []string{"https://embedery.com/widget/*"}, "https://embedery.com/api/oembed"},
+	{"Embedly", []string{"http://cdn.embedly.com/widgets/media.html"}, "https://api.embed.ly/1/oembed"},
+	{"Enystre Music", []string{"https://music.enystre.com/lyrics/*"}, "https://music.enystre.com/oembed/"},
+	{"Ethfiddle", []string{"https://ethfiddle.com/*"}, "https://ethfiddle.com/services/oembed/"},
+	{"EventLive", []string{"https://evt.live/*", "https://evtlive.com/*", "https://event.live/*"}, "https://evt.live/api/oembed"},
+	{"Eyrie", []string{"https://eyrie.io/board/*", "https://eyrie.io/sparkfun/*"}, "https://eyrie.io/v1/oembed"},
+	{"Facebook", []string{"https://www.facebook.com/*/posts/*", "https://www.facebook.com/*/activity/*", "https://www.facebook.com/photo.php?fbid=*", "https://www.facebook.com/photos/*", "https://www.facebook.com/permalink.php?story_fbid=*", "https://www.facebook.com/media/set?set=*", "https://www.facebook.com/questions/*", "https://www.facebook.com/notes/*/*/*"}, "https://graph.facebook.com/v16.0/oembed_post"},
+	{"Facebook", []string{"https://www.facebook.com/*/videos/*", "https://www.facebook.com/video.php?id=*", "https://www.facebook.com/video.php?v=*"}, "https://graph.facebook.com/v16.0/oembed_video"},
+	{"Facebook", []string{"https://www.facebook.com/*"}, "https://graph.facebook.com/v16.0/oembed_page"},
+	{"Fader", []string{"https://app.getfader.com/projects/*/publish"}, "https://app.getfader.com/api/oembed"},
+	{"Faithlife TV", []string{"https://faithlifetv.com/items/*", "https://faithlifetv.com/items/resource/*/*", "https://faithlifetv.com/media/*", "https://faithlifetv.com/media/assets/*", "https://faithlifetv.com/media/resource/*/*"}, "https://faithlifetv.com/api/oembed"},
+	{"Figma", []string{"https://www.figma.com/file/*", "https://www.figma.com/proto/*", "https://www.figma.com/design/*"}, "https://www.figma.com/api/oembed"},
+	{"Firework", []string{"https://*.fireworktv.com/*", "https://*.fireworktv.com/embed/*/v/*"}, "https://www.fireworktv.com/oembed"},
+	{"FITE", []string{"https://www.fite.tv/watch/*"}, "https://www.fite.tv/oembed"},
+	{"Flat", []string{"https://flat.io/score/*", "https://*.flat.io/score/*"}, "https://flat.io/services/oembed"},
+	{"Flickr", []string{"http://*.flickr.com/photos/*", "http://flic.kr/p/*", "https://*.flickr.com/photos/*", "https://flic.kr/p/*", "https://*.*.flickr.com/photos/*", "http://*.*.flickr.com/photos/*"}, "https://www.flickr.com/services/oembed/"},
+	{"Flourish", []string{"https://public.flourish.studio/visualisation/*", "https://public.flourish.studio/story/*"}, "https://app.flourish.studio/api/v1/oembed"},
+	{"FOX SPORTS Australia", []string{"http://fiso.foxsports.com.au/isa/*", "https://fiso.foxsports.com.au/isa/*"}, "https://fiso.foxsports.com.au/oembed"},
+	{"FrameBuzz", []string{"http://framebuzz.com/v/*", "https://framebuzz.com/v/*"}, "https://framebuzz.com/oembed/"},
+	{"Geograph Britain and Ireland", []string{"https://*.geograph.org.uk/*", "http://*.geograph.org.uk/*", "http://*.geograph.co.uk/*", "http://*.geograph.ie/*", "http://*.wikimedia.org/*_geograph.org.uk_*"}, "http://api.geograph.org.uk/api/oembed"},
+	{"Geograph Channel Islands", []string{"http://*.geograph.org.gg/*", "http://*.geograph.org.je/*", "http://channel-islands.geograph.org/*", "http://channel-islands.geographs.org/*", "http://*.channel.geographs.org/*"}, "http://www.geograph.org.gg/api/oembed"},
+	{"Geograph Germany", []string{"http://geo-en.hlipp.de/*", "http://geo.hlipp.de/*", "http://germany.geograph.org/*"}, "http://geo.hlipp.de/restapi.php/api/oembed"},
+	{"Getty Images", []string{"http://gty.im/*"}, "http://embed.gettyimages.com/oembed"},
+	{"Gfycat", []string{"http://gfycat.com/*", "http://www.gfycat.com/*", "https://gfycat.com/*", "https://www.gfycat.com/*"}, "https://api.gfycat.com/v1/oembed"},
+	{"Gifnote", []string{"https://www.gifnote.com/play/*"}, "https://www.gifnote.com/services/oembed"},
+	{"GIPHY", []string{"https://giphy.com/gifs/*", "https://giphy.com/clips/*", "http://gph.is/*", "https://media.giphy.com/media/*/giphy.gif"}, "https://giphy.com/services/oembed"},
+	{"GloriaTV", []string{"https://gloria.tv/*"}, "https://gloria.tv/oembed/"},
+	{"GoPro", []string{"https://gopro.com/v/*"}, "https://api.gopro.com/oembed"},
+	{"Gumlet", []string{"https://gumlet.tv/watch/*", "https://play.gumlet.io/embed/*"}, "https://api.gumlet.com/v1/oembed"},
+	{"Gyazo", []string{"https://gyazo.com/*"}, "https://api.gyazo.com/api/oembed"},
+	{"hearthis.at", []string{"https://hearthis.at/*/*/", "https://hearthis.at/*/set/*/"}, "https://hearthis.at/oembed/?format=json"},
+	{"Hihaho", []string{"https://player.hihaho.com/*"}, "https://player.hihaho.com/services/oembed"},
+	{"Homey", []string{"https://homey.app/f/*", "https://homey.app/*/flow/*"}, "https://homey.app/api/oembed/flow"},
+	{"HuffDuffer", []string{"http://huffduffer.com/*/*"}, "http://huffduffer.com/oembed"},
+	{"Hulu", []string{"http://www.hulu.com/watch/*"}, "http://www.hulu.com/api/oembed.{format}"},
+	{"Ifixit", []string{"http://www.ifixit.com/Guide/View/*"}, "http://www.ifixit.com/Embed"},
+	{"IFTTT", []string{"http://ifttt.com/recipes/*"}, "http://www.ifttt.com/oembed/"},
+	{"Imgur", []string{"https://imgur.com/*", "https://imgur.com/gallery/*", "https://imgur.com/a/*", "https://i.imgur.com/*"}, "https://api.imgur.com/oembed"},
+	{"Incredible", []string{"https://incredible.dev/watch/*"}, "https://incredible.dev/oembed"},
+	{"Indaco", []string{"https://player.indacolive.com/player/jwp/clients/*"}, "https://player.indacolive.com/services/oembed"},
+	{"Infogram", []string{"https://infogram.com/*"}, "https://infogram.com/oembed"},
+	{"Infoveave", []string{"https://*.infoveave.net/E/*", "https://*.infoveave.net/P/*"}, "https://infoveave.net/services/oembed/"},
+	{"Injurymap", []string{"https://www.injurymap.com/exercises/*"}, "https://www.injurymap.com/services/oembed"},
+	{"Inoreader", []string{"https://www.inoreader.com/oembed/"}, "https://www.inoreader.com/oembed/api/"},
+	{"inphood", []string{"http://*.inphood.com/*"}, "http://api.inphood.com/oembed"},
+	{"Insight Timer", []string{"https://insighttimer.com/*"}, "https://widgets.insighttimer.com/services/oembed"},
+	{"Instagram", []string{"http://instagram.com/p/*", "http://instagr.am/p/*", "http://www.instagram.com/p/*", "http://www.instagr.am/p/*", "https://instagram.com/p/*", "https://instagr.am/p/*", "https://www.instagram.com/p/*", "https://www.instagr.am/p/*", "http://instagram.com/*/p/*", "http://www.instagram.com/*/p/*", "https://instagram.com/*/p/*", "https://www.instagram.com/*/p/*", "http://instagram.com/tv/*", "http://instagr.am/tv/*", "http://www.instagram.com/tv/*", "http://www.instagr.am/tv/*", "https://instagram.com/tv/*", "https://instagr.am/tv/*", "https://www.instagram.com/tv/*", "https://www.instagr.am/tv/*", "http://www.instagram.com/reel/*", "https://www.instagram.com/reel/*", "http://instagram.com/reel/*", "https://instagram.com/reel/*", "http://instagr.am/reel/*", "https://instagr.am/reel/*"}, "https://graph.facebook.com/v16.0/instagram_oembed"},
+	{"Issuu", []string{"https://issuu.com/*/docs/*"}, "https://issuu.com/oembed"},
+	{"KakaoTv", []string{"https://tv.kakao.com/channel/*/cliplink/*", "https://tv.kakao.com/m/channel/*/cliplink/*", "https://tv.kakao.com/channel/v/*", "https://tv.kakao.com/channel/*/livelink/*", "https://tv.kakao.com/m/channel/*/livelink/*", "https://tv.kakao.com/channel/l/*"}, "https://tv.kakao.com/oembed"},
+	{"Kickstarter", []string{"http://www.kickstarter.com/projects/*"}, "http://www.kickstarter.com/services/oembed"},
+	{"Kidoju", []string{"https://www.kidoju.com/en/x/*/*", "https://www.kidoju.com/fr/x/*/*"}, "https://www.kidoju.com/api/oembed"},
+	{"Kirim.Email", []string{"https://halaman.email/form/*", "https://aplikasi.kirim.email/form/*"}, "https://halaman.email/service/oembed"},
+	{"Kit", []string{"http://kit.co/*/*", "https://kit.co/*/*"}, "https://embed.kit.co/oembed"},
+	{"Kitchenbowl", []string{"http://www.kitchenbowl.com/recipe/*"}, "http://www.kitchenbowl.com/oembed"},
+	{"kmdr", []string{"https://app.kmdr.sh/h/*", "https://app.kmdr.sh/history/*"}, "https://api.kmdr.sh/services/oembed"},
+	{"Knacki", []string{"http://jdr.knacki.info/meuh/*", "https://jdr.knacki.info/meuh/*"}, "https://jdr.knacki.info/oembed"},
+	{"Knowledge Pad", []string{"https://knowledgepad.co/#/knowledge/*"}, "https://api.spoonacular.com/knowledge/oembed"},
+	{"LearningApps.org", []string{"http://learningapps.org/*"}, "http://learningapps.org/oembed.php"},
+	{"LeMansPodcasts", []string{"https://lemanspodcasts.com/*"}, "https://podcasts.audiomeans.fr/services/oembed"},
+	{"Lille.Pod", []string{"https://lille.pod.univ-lille.fr/video/*"}, "https://lille.pod.univ-lille.fr/oembed"},
+	{"Livestream", []string{"https://livestream.com/accounts/*/events/*", "https://livestream.com/accounts/*/events/*/videos/*", "https://livestream.com/*/events/*", "https://livestream.com/*/events/*/videos/*", "https://livestream.com/*/*", "https://livestream.com/*/*/videos/*"}, "https://livestream.com/oembed"},
+	{"Loom", []string{"https://loom.com/i/*", "https://loom.com/share/*", "https://www.loom.com/i/*", "https://www.loom.com/share/*"}, "https://www.loom.com/v1/oembed"},
+	{"LottieFiles", []string{"https://lottiefiles.com/*", "https://*.lottiefiles.com/*", "https://*.lottiefiles.com/*/*", "https://lottiefiles.com/*/*"}, "https://embed.lottiefiles.com/oembed"},
+	{"Ludus", []string{"https://app.ludus.one/*"}, "https://app.ludus.one/oembed"},
+	{"MathEmbed", []string{"http://mathembed.com/latex?inputText=*", "https://mathembed.com/latex?inputText=*"}, "http://mathembed.com/oembed"},
+	{"Matterport", []string{"https://my.matterport.com/show/*"}, "https://my.matterport.com/api/v1/models/oembed/"},
+	{"me.me", []string{"https://me.me/i/*"}, "https://me.me/oembed"},
+	{"Medienarchiv der Künste - Zürcher Hochschule der Künste", []string{"https://medienarchiv.zhdk.ch/entries/*"}, "https://medienarchiv.zhdk.ch/oembed.{format}"},
+	{"Mermaid Ink", []string{"https://mermaid.ink/img/*", "https://mermaid.ink/svg/*"}, "https://mermaid.ink/services/oembed"},
+	{"Microsoft Stream", []string{"https://*.microsoftstream.com/video/*", "https://*.microsoftstream.com/channel/*"}, "https://web.microsoftstream.com/oembed"},
+	{"Minerva", []string{"https://www.minervaknows.com/featured-recipes/*", "https://www.minervaknows.com/themes/*", "https://www.minervaknows.com/themes/*/recipes/*", "https://app.minervaknows.com/recipes/*", "https://app.minervaknows.com/recipes/*/follow"}, "https://oembed.minervaknows.com"},
+	{"Miro", []string{"https://miro.com/app/board/*"}, "https://miro.com/api/v1/oembed"},
+	{"MixCloud", []string{"http://www.mixcloud.com/*/*/", "https://www.mixcloud.com/*/*/"}, "https://app.mixcloud.com/oembed/"},
+	{"Moby Picture", []string{"http://www.mobypicture.com/user/*/view/*", "http://moby.to/*"}, "http://api.mobypicture.com/oEmbed"},
+	{"Modelo", []string{"https://beta.modelo.io/embedded/*"}, "https://portal.modelo.io/oembed"},
+	{"MorphCast", []string{"https://m-roll.morphcast.com/mroll/*"}, "https://m-roll.morphcast.com/service/oembed"},
+	{"Music Box Maniacs", []string{"https://musicboxmaniacs.com/explore/melody/*"}, "https://musicboxmaniacs.com/embed/"},
+	{"myBeweeg", []string{"https://mybeweeg.com/w/*"}, "https://mybeweeg.com/services/oembed"},
+	{"Namchey", []string{"https://namchey.com/embeds/*"}, "https://namchey.com/api/oembed"},
+	{"nanoo.tv", []string{"http://*.nanoo.tv/link/*", "http://nanoo.tv/link/*", "http://*.nanoo.pro/link/*", "http://nanoo.pro/link/*", "https://*.nanoo.tv/link/*", "https://nanoo.tv/link/*", "https://*.nanoo.pro/link/*", "https://nanoo.pro/link/*", "http://media.zhdk.ch/signatur/*", "http://new.media.zhdk.ch/signatur/*", "https://media.zhdk.ch/signatur/*", "https://new.media.zhdk.ch/signatur/*"}, "https://www.nanoo.tv/services/oembed"},
+	{"Nasjonalbiblioteket", []string{"https://www.nb.no/items/*"}, "https://api.nb.no/catalog/v1/oembed"},
+	{"Natural Atlas", []string{"https://naturalatlas.com/*", "https://naturalatlas.com/*/*", "https://naturalatlas.com/*/*/*", "https://naturalatlas.com/*/*/*/*"}, "https://naturalatlas.com/oembed.{format}"},
+	{"nfb.ca", []string{"http://*.nfb.ca/film/*"}, "http://www.nfb.ca/remote/services/oembed/"},
+	{"Observable", []string{"https://observablehq.com/@*/*", "https://observablehq.com/d/*", "https://observablehq.com/embed/*"}, "https://api.observablehq.com/oembed"},
+	{"Odds.com.au", []string{"https://www.odds.com.au/*", "https://odds.com.au/*"}, "https://www.odds.com.au/api/oembed/"},
+	{"Odesli (formerly Songlink)", []string{"https://song.link/*", "https://album.link/*", "https://artist.link/*", "https://playlist.link/*", "https://pods.link/*", "https://mylink.page/*", "https://odesli.co/*"}, "https://song.link/oembed"},
+	{"Odysee", []string{"https://odysee.com/*/*", "https://odysee.com/*"}, "https://odysee.com/$/oembed"},
+	{"Official FM", []string{"http://official.fm/tracks/*", "http://official.fm/playlists/*"}, "http://official.fm/services/oembed.{format}"},
+	{"Omniscope", []string{"https://omniscope.me/*"}, "https://omniscope.me/_global_/oembed/json"},
+	{"Omny Studio", []string{"https://omny.fm/shows/*"}, "https://omny.fm/oembed"},
+	{"Orbitvu", []string{"https://orbitvu.co/001/*/ov3601/view", "https://orbitvu.co/001/*/ov3601/*/view", "https://orbitvu.co/001/*/ov3602/*/view", "https://orbitvu.co/001/*/2/orbittour/*/view", "https://orbitvu.co/001/*/1/2/orbittour/*/view", "http://orbitvu.co/001/*/ov3601/view", "http://orbitvu.co/001/*/ov3601/*/view", "http://orbitvu.co/001/*/ov3602/*/view", "http://orbitvu.co/001/*/2/orbittour/*/view", "http://orbitvu.co/001/*/1/2/orbittour/*/view"}, "http://orbitvu.co/service/oembed"},
+	{"Origits", []string{"https://origits.com/v/*", "https://origits.net/v/*"}, "https://origits.net/oembed"},
+	{"Outplayed.tv", []string{"https://outplayed.tv/media/*"}, "https://outplayed.tv/oembed"},
+	{"Overflow", []string{"https://overflow.io/s/*", "https://overflow.io/embed/*"}, "https://overflow.io/services/oembed"},
+	{"OZ", []string{"https://www.oz.com/*/video/*"}, "https://core.oz.com/oembed"},
+	{"Padlet", []string{"https://padlet.com/*"}, "https://padlet.com/oembed/"},
+	{"Pastery", []string{"http://pastery.net/*", "https://pastery.net/*", "http://www.pastery.net/*", "https://www.pastery.net/*"}, "https://pastery.net/oembed"},
+	{"PingVP", []string{"https://www.pingvp.com/*"}, "https://beta.pingvp.com.kpnis.nl/p/oembed.php"},
+	{"Pinpoll", []string{"https://tools.pinpoll.com/embed/*"}, "https://tools.pinpoll.com/oembed"},
+	{"Pitchhub", []string{"https://player.pitchhub.com/en/public/player/*"}, "https://player.pitchhub.com/en/public/oembed"},
+	{"Pixdor", []string{"http://store.pixdor.com/place-marker-widget/*/show", "https://store.pixdor.com/map/*/show"}, "https://store.pixdor.com/oembed"},
+	{"Podbean", []string{"http://*.podbean.com/e/*", "https://*.podbean.com/e/*"}, "https://api.podbean.com/v1/oembed"},
+	{"Polaris Share", []string{"https://www.polarishare.com/*/*"}, "https://api.polarishare.com/rest/api/oembed"},
+	{"Poll Daddy", []string{"http://*.polldaddy.com/s/*", "http://*.polldaddy.com/poll/*", "http://*.polldaddy.com/ratings/*"}, "http://polldaddy.com/oembed/"},
+	{"Portfolium", []string{"https://portfolium.com/entry/*"}, "https://api.portfolium.com/oembed"},
+	{"posiXion", []string{"https://posixion.com/question/*", "https://posixion.com/*/question/*"}, "http://posixion.com/services/oembed/"},
+	{"Prezi Video", []string{"https://prezi.com/v/*", "https://*.prezi.com/v/*"}, "https://prezi.com/v/oembed"},
+	{"Quiz.biz", []string{"http://www.quiz.biz/quizz-*.html"}, "http://www.quiz.biz/api/oembed"},
+	{"Quizz.biz", []string{"http://www.quizz.biz/quizz-*.html"}, "http://www.quizz.biz/api/oembed"},
+	{"RadioPublic", []string{"https://play.radiopublic.com/*", "https://radiopublic.com/*", "https://www.radiopublic.com/*", "http://play.radiopublic.com/*", "http://radiopublic.com/*", "http://www.radiopublic.com/*", "https://*.radiopublic.com/*"}, "https://oembed.radiopublic.com/oembed"},
+	{"RapidEngage", []string{"https://rapidengage.com/s/*"}, "https://rapidengage.com/api/oembed"},
+	{"Reddit", []string{"https://reddit.com/r/*/comments/*/*", "https://www.reddit.com/r/*/comments/*/*"}, "https://www.reddit.com/oembed"},
+	{"ReleaseWire", []string{"http://rwire.com/*"}, "http://publisher.releasewire.com/oembed/"},
+	{"Replit", []string{"https://repl.it/@*/*", "https://replit.com/@*/*"}, "https://replit.com/data/oembed"},
+	{"RepubHub", []string{"http://repubhub.icopyright.net/freePost.act?*"}, "http://repubhub.icopyright.net/oembed.act"},
+	{"ReverbNation", []string{"https://www.reverbnation.com/*", "https://www.reverbnation.com/*/songs/*"}, "https://www.reverbnation.com/oembed"},
+	{"RoomShare", []string{"http://roomshare.jp/post/*", "http://roomshare.jp/en/post/*"}, "http://roomshare.jp/en/oembed.{format}"},
+	{"RoosterTeeth", []string{"https://roosterteeth.com/*"}, "https://roosterteeth.com/oembed"},
+	{"Runkit", []string{"http://embed.runkit.com/*", "https://embed.runkit.com/*"}, "https://embed.runkit.com/oembed"},
+	{"Saooti", []string{"https://octopus.saooti.com/main/pub/podcast/*"}, "https://octopus.saooti.com/oembed"},
+	{"Sapo Videos", []string{"http://videos.sapo.pt/*"}, "http://videos.sapo.pt/oembed"},
+	{"Screen9", []string{"https://console.screen9.com/*", "https://*.screen9.tv/*"}, "https://api.screen9.com/oembed"},
+	{"Screencast.com", []string{"http://www.screencast.com/*"}, "https://api.screencast.com/external/oembed"},
+	{"Screenr", []string{"http://www.screenr.com/*/"}, "http://www.screenr.com/api/oembed.{format}"},
+	{"ScribbleMaps", []string{"http://scribblemaps.com/maps/view/*", "https://scribblemaps.com/maps/view/*", "http://www.scribblemaps.com/maps/view/*", "https://www.scribblemaps.com/maps/view/*"}, "https://scribblemaps.com/api/services/oembed.{format}"},
+	{"Scribd", []string{"http://www.scribd.com/doc/*", "https://www.scribd.com/doc/*", "https://www.scribd.com/document/*"}, "http://www.scribd.com/services/oembed/"},
+	{"SendtoNews", []string{"https://embed.sendtonews.com/oembed/*"}, "https://embed.sendtonews.com/services/oembed"},
+	{"ShortNote", []string{"https://www.shortnote.jp/view/notes/*"}, "https://www.shortnote.jp/oembed/"},
+	{"Shoudio", []string{"http://shoudio.com/*", "http://shoud.io/*"}, "http://shoudio.com/api/oembed"},
+	{"Show the Way, actionable location info", []string{"https://showtheway.io/to/*"}, "https://showtheway.io/oembed"},
+	{"Simplecast", []string{"https://simplecast.com/s/*"}, "https://simplecast.com/oembed"},
+	{"Sizzle", []string{"https://onsizzle.com/i/*"}, "https://onsizzle.com/oembed"},
+	{"Sketchfab", []string{"http://sketchfab.com/models/*", "https://sketchfab.com/models/*", "https://sketchfab.com/*/folders/*"}, "http://sketchfab.com/oembed"},
+	{"SlideShare", []string{"https://www.slideshare.net/*/*", "http://www.slideshare.net/*/*", "https://fr.slideshare.net/*/*", "http://fr.slideshare.net/*/*", "https://de.slideshare.net/*/*", "http://de.slideshare.net/*/*", "https://es.slideshare.net/*/*", "http://es.slideshare.net/*/*", "https://pt.slideshare.net/*/*", "http://pt.slideshare.net/*/*"}, "https://www.slideshare.net/api/oembed/2"},
+	{"SmashNotes", []string{"https://smashnotes.com/p/*", "https://smashnotes.com/p/*/e/*", "https://smashnotes.com/p/*/e/*/s/*"}, "https://smashnotes.com/services/oembed"},
+	{"SmugMug", []string{"http://*.smugmug.com/*", "https://*.smugmug.com/*"}, "https://api.smugmug.com/services/oembed/"},
+	{"SocialExplorer", []string{"https://www.socialexplorer.com/*/explore", "https://www.socialexplorer.com/*/view", "https://www.socialexplorer.com/*/edit", "https://www.socialexplorer.com/*/embed"}, "https://www.socialexplorer.com/services/oembed/"},
+	{"SoundCloud", []string{"http://soundcloud.com/*", "https://soundcloud.com/*", "https://on.soundcloud.com/*", "https://soundcloud.app.goog.gl/*"}, "https://soundcloud.com/oembed"},
+	{"Speaker Deck", []string{"http://speakerdeck.com/*/*", "https://speakerdeck.com/*/*"}, "https://speakerdeck.com/oembed.json"},
+	{"Spotify", []string{"https://open.spotify.com/*", "spotify:*"}, "https://open.spotify.com/oembed/"},
+	{"Spreaker", []string{"http://*.spreaker.com/*", "https://*.spreaker.com/*"}, "https://api.spreaker.com/oembed"},
+	{"Streamable", []string{"http://streamable.com/*", "https://streamable.com/*"}, "https://api.streamable.com/oembed.json"},
+	{"StreamOneCloud", []string{"https://content.streamonecloud.net/embed/*"}, "https://content.streamonecloud.net/oembed"},
+	{"Sutori", []string{"https://www.sutori.com/story/*"}, "https://www.sutori.com/api/oembed"},
+	{"Sway", []string{"https://sway.com/*", "https://www.sway.com/*"}, "https://sway.com/api/v1.0/oembed"},
+	{"Sway Office", []string{"https://sway.office.com/*"}, "https://sway.office.com/api/v1.0/oembed"},
+	{"TED", []string{"http://ted.com/talks/*", "https://ted.com/talks/*", "https://www.ted.com/talks/*"}, "https://www.ted.com/services/v1/oembed.{format}"},
+	{"The New York Times", []string{"https://www.nytimes.com/svc/oembed", "https://nytimes.com/*", "https://*.nytimes.com/*"}, "https://www.nytimes.com/svc/oembed/json/"},
+	{"They Said So", []string{"https://theysaidso.com/image/*"}, "https://theysaidso.com/extensions/oembed/"},
+	{"TickCounter", []string{"http://www.tickcounter.com/countdown/*", "http://www.tickcounter.com/countup/*", "http://www.tickcounter.com/ticker/*", "http://www.tickcounter.com/worldclock/*", "https://www.tickcounter.com/countdown/*", "https://www.tickcounter.com/countup/*", "https://www.tickcounter.com/ticker/*", "https://www.tickcounter.com/worldclock/*"}, "https://www.tickcounter.com/oembed"},
+	{"TikTok", []string{"https://www.tiktok.com/*", "https://www.tiktok.com/*/video/*"}, "https://www.tiktok.com/oembed"},
+	{"Toornament", []string{"https://www.toornament.com/tournaments/*/information", "https://www.toornament.com/tournaments/*/registration/", "https://www.toornament.com/tournaments/*/matches/schedule", "https://www.toornament.com/tournaments/*/stages/*/"}, "https://widget.toornament.com/oembed"},
+	{"Topy", []string{"http://www.topy.se/image/*"}, "http://www.topy.se/oembed/"},
+	{"Trinity Audio", []string{"https://trinitymedia.ai/player/*", "https://trinitymedia.ai/player/*/*"}, "https://trinitymedia.ai/player/trinity-oembed"},
+	{"Tumblr", []string{"https://*.tumblr.com/post/*"}, "https://www.tumblr.com/oembed/1.0"},
+	{"Twitch", []string{"https://clips.twitch.tv/*", "https://www.twitch.tv/*", "http://clips.twitch.tv/*", "http://www.twitch.tv/*", "http://twitch.tv/*", "https://twitch.tv/*"}, "https://api.twitch.tv/v5/oembed"},
+	{"Twitter", []string{"https://twitter.com/*", "https://twitter.com/*/status/*", "https://*.twitter.com/*/status/*"}, "https://publish.twitter.com/oembed"},
+	{"TypeCast", []string{"https://play.typecast.ai/s/*", "https://play.typecast.ai/e/*", "https://play.typecast.ai/*"}, "https://play.typecast.ai/oembed"},
+	{"Ubideo", []string{"https://player.ubideo.com/*"}, "https://player.ubideo.com/api/oembed.json"},
+	{"University of Cambridge Map", []string{"https://map.cam.ac.uk/*"}, "https://map.cam.ac.uk/oembed/"},
+	{"UnivParis1.Pod", []string{"https://mediatheque.univ-paris1.fr/video/*"}, "https://mediatheque.univ-paris1.fr/oembed"},
+	{"Upec.Pod", []string{"https://pod.u-pec.fr/video/*"}, "https://pod.u-pec.fr/oembed"},
+	{"Ustream", []string{"http://*.ustream.tv/*", "http://*.ustream.com/*"}, "http://www.ustream.tv/oembed"},
+	{"uStudio, Inc.", []string{"https://*.ustudio.com/embed/*", "https://*.ustudio.com/embed/*/*"}, "https://app.ustudio.com/api/v2/oembed"},
+	{"Verse", []string{"http://verse.com/stories/*"}, "http://verse.com/services/oembed/"},
+	{"VideoJug", []string{"http://www.videojug.com/film/*", "http://www.videojug.com/interview/*"}, "http://www.videojug.com/oembed.{format}"},
+	{"Vidlit", []string{"https://vidl.it/*"}, "https://api.vidl.it/oembed"},
+	{"Vidmizer", []string{"https://players-cdn-v2.vidmizer.com/*"}, "https://app-v2.vidmizer.com/api/oembed"},
+	{"Vidyard", []string{"http://embed.vidyard.com/*", "http://play.vidyard.com/*", "http://share.vidyard.com/*", "http://*.hubs.vidyard.com/*"}, "https://api.vidyard.com/dashboard/v1.1/oembed"},
+	{"Vimeo", []string{"https://vimeo.com/*", "https://vimeo.com/album/*/video/*", "https://vimeo.com/channels/*/*", "https://vimeo.com/groups/*/videos/*", "https://vimeo.com/ondemand/*/*", "https://player.vimeo.com/video/*", "https://vimeo.com/event/*/*"}, "https://vimeo.com/api/oembed.{format}"},
+	{"Viously", []string{"https://www.viously.com/*/*"}, "https://www.viously.com/oembed"},
+	{"Viostream", []string{"https://share.viostream.com/*"}, "https://play.viostream.com/oembed"},
+	{"Vlipsy", []string{"https://vlipsy.com/*"}, "https://vlipsy.com/oembed"},
+	{"Vouch", []string{"https://*.vouchfor.com/*"}, "https://embed.vouchfor.com/v1/oembed"},
+	{"VoxSnap", []string{"https://article.voxsnap.com/*/*"}, "https://data.voxsnap.com/oembed"},
+	{"Waltrack", []string{"https://waltrack.net/product/*"}, "https://waltrack.net/oembed"},
+	{"Wave.video", []string{"https://watch.wave.video/*", "https://embed.wave.video/*"}, "https://embed.wave.video/oembed"},
+	{"wecandeo", []string{"https://play.wecandeo.com/video/v/*"}, "https://play.wecandeo.com/oembed/"},
+	{"Wistia, Inc.", []string{"https://fast.wistia.com/embed/iframe/*", "https://fast.wistia.com/embed/playlists/*", "https://*.wistia.com/medias/*"}, "https://fast.wistia.com/oembed.{format}"},
+	{"wizer.me", []string{"http://*.wizer.me/learn/*", "https://*.wizer.me/learn/*", "http://*.wizer.me/preview/*", "https://*.wizer.me/preview/*"}, "http://app.wizer.me/api/oembed.{format}"},
+	{"Wokwi", []string{"https://wokwi.com/share/*"}, "https://wokwi.com/api/oembed"},
+	{"Wolfram Cloud", []string{"https://*.wolframcloud.com/*"}, "https://www.wolframcloud.com/oembed"},
+	{"WordPress.tv", []string{"https://wordpress.tv/*"}, "https://wordpress.tv/oembed/"},
+	{"Xpression", []string{"https://web.xpression.jp/video/*"}, "https://web.xpression.jp/api/oembed"},
+	{"YouTube", []string{"https://*.youtube.com/watch*", "https://*.youtube.com/v/*", "https://youtu.be/*", "https://*.youtube.com/playlist?list=*", "https://youtube.com/playlist?list=*", "https://*.youtube.com/shorts*", "https://youtube.com/shorts*", "https://*.youtube.com/embed/*", "https://*.youtube.com/live*", "https://youtube.com/live*"}, "https://www.youtube.com/oembed"},
+	{"Zeplin", []string{"https://app.zeplin.io/project/*/screen/*", "https://app.zeplin.io/project/*/screen/*/version/*", "https://app.zeplin.io/project/*/styleguide/components?coid=*", "https://app.zeplin.io/styleguide/*/components?coid=*"}, "https://app.zeplin.io/embed/oembed"},
+	{"ZingSoft", []string{"https://app.zingsoft.com/embed/*", "https://app.zingsoft.com/view/*"}, "https://app.zingsoft.com/oembed"},
+	{"ZnipeTV", []string{"https://*.znipe.tv/*"}, "https://api.znipe.tv/v3/oembed/"},
+}
@@ -0,0 +1,92 @@
+package utils
+
+import "testing"
+
+func TestWildcardMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"https://*.youtube.com/watch*", "https://www.youtube.com/watch?v=abc", true},
+		{"https://*.youtube.com/watch*", "https://m.youtube.com/watch", true},
+		{"https://*.youtube.com/watch*", "https://youtube.com/watch?v=abc", false},
+		{"https://*.youtube.com/watch*", "https://evil.com/x.youtube.com/watch?v=1", false},
+		{"https://*.youtube.com/watch*", "https://evil.com/?.youtube.com/watch", false},
+		{"https://*.youtube.com/watch*", "https://evil.com#.youtube.com/watch", false},
+		{"https://*.youtube.com/watch*", "https://a.b.youtube.com/watch", false},
+		{"https://*.*.flickr.com/photos/*", "https://farm1.static.flickr.com/photos/x", true},
+		{"https://vimeo.com/*", "https://vimeo.com/123", true},
+		{"https://vimeo.com/*", "https://vimeo.com/a/b", false},
+		{"https://vimeo.com/channels/*/*", "https://vimeo.com/channels/staff/123", true},
+		{"https://www.tiktok.com/*/video/*", "https://www.tiktok.com/@user/video/1", true},
+		{"https://www.canva.com/design/*/view", "https://www.canva.com/design/abc/view", true},
+		{"https://www.canva.com/design/*/view", "https://www.canva.com/design/abc/edit", false},
+		{"http://www.quiz.biz/quizz-*.html", "http://www.quiz.biz/quizz-123.html", true},
+		{"spotify:*", "spotify:track:1", true},
+		{"https://youtu.be/*", "https://youtu.be/abc", true},
+		{"https://youtu.be/*", "https://youtu.be.evil.com/abc", false},
+		{"https://example.com/video", "https://example.com/video", true},
+		{"https://example.com/video", "https://example.com/video/1", false},
+	}
+
+	for _, test := range tests {
+		if matched := wildcardMatch(test.pattern, test.value); matched != test.want {
+			t.Errorf("wildcardMatch(%q, %q) = %v, want %v", test.pattern, test.value, matched, test.want)
+		}
+	}
+}
+
+func TestFindOEmbedProvider(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://www.youtube.com/watch?v=abc", "YouTube"},
+		{"http://youtu.be/abc", "YouTube"},
+		{"https://vimeo.com/123", "Vimeo"},
+		{"https://www.kickstarter.com/projects/x", "Kickstarter"},
+		{"https://soundcloud.com/artist", "SoundCloud"},
+		{"https://example.com/watch?v=abc", ""},
+	}
+
+	for _, test := range tests {
+		name := ""
+		if provider := FindOEmbedProvider(test.url); provider != nil {
+			name = provider.Name
+		}
+
+		if name != test.want {
+			t.Errorf("FindOEmbedProvider(%q) = %q, want %q", test.url, name, test.want)
+		}
+	}
+}
+
+func TestParseOEmbed(t *testing.T) {
+	tests := []struct {
+		body string
+		want OEmbed
+	}{
+		{
+			`{"type": "video", "title": " A Video ", "author_name": "Someone", "width": "640", "height": 360, "thumbnail_url": "javascript:x"}`,
+			OEmbed{Type: "video", Title: "A Video", AuthorName: "Someone", Width: 640, Height: 360},
+		},
+		{
+			`<?xml version="1.0"?><oembed><type>Photo</type><url>https://example.com/a.jpg</url><width>100%</width></oembed>`,
+			OEmbed{Type: "photo", URL: "https://example.com/a.jpg"},
+		},
+	}
+
+	for _, test := range tests {
+		oembed, err := ParseOEmbed([]byte(test.body))
+		if err != nil {
+			t.Errorf("ParseOEmbed(%q) returned an error: %v", test.body, err)
+		} else if *oembed != test.want {
+			t.Errorf("ParseOEmbed(%q) = %+v, want %+v", test.body, *oembed, test.want)
+		}
+	}
+
+	if _, err := ParseOEmbed([]byte(`{"title": "No type"}`)); err == nil {
+		t.Errorf("ParseOEmbed without a type should return an error")
+	}
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Link is a link (`<a href>`) on the page.
+type Link struct {
+	URL       string   `json:"url"`
+	Text      string   `json:"text,omitempty"` // Anchor text - or the alt text of a linked image
+	Rel       []string `json:"rel,omitempty"`
+	External  bool     `json:"external"`
+	NoFollow  bool     `json:"nofollow,omitempty"`
+	Sponsored bool     `json:"sponsored,omitempty"`
+	UGC       bool     `json:"ugc,omitempty"`
+	Social    string   `json:"social,omitempty"` // Domain of the social network, for links to them
+}
+
+// LinkSummary has the counts of the links on the page.
+type LinkSummary struct {
+	Total     int            `json:"total"`
+	Internal  int            `json:"internal"`
+	External  int            `json:"external"`
+	NoFollow  int            `json:"nofollow"`
+	Sponsored int            `json:"sponsored"`
+	UGC       int            `json:"ugc"`
+	Domains   map[string]int `json:"domains,omitempty"` // Links per external domain
+	Social    map[string]int `json:"social,omitempty"`  // Links per social network domain
+}
+
+// Links are all the links on the page, with the summary counts.
+type Links struct {
+	Links   []Link      `json:"links"`
+	Summary LinkSummary `json:"summary"`
+}
+
+// Domains of the social networks (and the alternate/short domains), mapped to the main domain.
+var socialDomains = map[string]string{
+	"twitter.com": "twitter.com", "x.com": "twitter.com", "facebook.com": "facebook.com", "fb.com": "facebook.com",
+	"instagram.com": "instagram.com", "linkedin.com": "linkedin.com", "youtube.com": "youtube.com",
+	"youtu.be": "youtube.com", "tiktok.com": "tiktok.com", "pinterest.com": "pinterest.com",
+	"github.com": "github.com", "reddit.com": "reddit.com", "threads.net": "threads.net",
+	"bsky.app": "bsky.app", "medium.com": "medium.com", "tumblr.com": "tumblr.com",
+	"snapchat.com": "snapchat.com", "t.me": "t.me", "telegram.me": "t.me", "discord.gg": "discord.gg",
+	"discord.com": "discord.gg", "twitch.tv": "twitch.tv", "vimeo.com": "vimeo.com",
+	"soundcloud.com": "soundcloud.com", "flickr.com": "flickr.com", "dribbble.com": "dribbble.com",
+	"behance.net": "behance.net", "vk.com": "vk.com", "weibo.com": "weibo.com",
+}
+
+/*
+ExtractLinks lists the http(s) links on the page, in document order, with the anchor
+text and the `rel` values. Links to another host than the page's (ignoring `www.`)
+are external, and links to the social networks are marked with the network's domain.
+Fragment-only links (eg: `#top`) are left out.
+*/
+func ExtractLinks(doc *goquery.Document) *Links {
+	links := &Links{
+		Links: []Link{},
+		Summary: LinkSummary{
+			Domains: make(map[string]int),
+			Social:  make(map[string]int),
+		},
+	}
+
+	pageHost := ""
+	if doc.Url != nil {
+		pageHost = linkHost(doc.Url)
+	}
+
+	doc.Find("a[href]").Each(func(_ int, s *goquery.Selection) {
+		href := strings.TrimSpace(attr(s, "href"))
+		if strings.HasPrefix(href, "#") {
+			return
+		}
+
+		linkURL := ResolveURL(doc, href)
+		parsed, err := url.Parse(linkURL)
+		if len(linkURL) == 0 || err != nil {
+			return
+		}
+
+		link := Link{
+			URL:  linkURL,
+			Text: collapseWhitespace(s.Text()),
+			Rel:  strings.Fields(strings.ToLower(attr(s, "rel"))),
+		}
+
+		if len(link.Text) == 0 {
+			link.Text = collapseWhitespace(firstNonEmptyString(attr(s.Find("img[alt]").First(), "alt"), attr(s, "title"), attr(s, "aria-label")))
+		}
+
+		for _, rel := range link.Rel {
+			switch rel {
+			case "nofollow":
+				link.NoFollow = true
+			case "sponsored":
+				link.Sponsored = true
+			case "ugc":
+				link.UGC = true
+			}
+		}
+
+		host := linkHost(parsed)
+		link.External = host != pageHost
+		link.Social = socialDomain(host)
+
+		links.add(link, host)
+	})
+
+	return links
+}
+
+// add adds the link, counting it in the summary.
+func (links *Links) add(link Link, host string) {
+	links.Links = append(links.Links, link)
+
+	summary := &links.Summary
+	summary.Total++
+
+	if link.External == true {
+		summary.External++
+		summary.Domains[host]++
+	} else {
+		summary.Internal++
+	}
+
+	if link.NoFollow == true {
+		summary.NoFollow++
+	}
+	if link.Sponsored == true {
+		summary.Sponsored++
+	}
+	if link.UGC == true {
+		summary.UGC++
+	}
+
+	if len(link.Social) > 0 {
+		summary.Social[link.Social]++
+	}
+}
+
+// linkHost returns the host of the URL, lowercased and without the port and the `www.` prefix.
+func linkHost(u *url.URL) string {
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// socialDomain returns the domain of the social network the host belongs to (including subdomains, eg: `m.facebook.com`).
+func socialDomain(host string) string {
+	for {
+		if domain, ok := socialDomains[host]; ok == true {
+			return domain
+		}
+
+		index := strings.Index(host, ".")
+		if index < 0 {
+			return ""
+		}
+		host = host[index+1:]
+	}
+}
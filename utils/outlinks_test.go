@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractLinks(t *testing.T) {
+	doc := newTestDocument(t, "https://www.example.com/post", `<html><body>
+		<a href="#comments">Comments</a>
+		<a href="/about">About
+			us</a>
+		<a href="https://example.com/contact"><img src="/mail.png" alt="Contact"></a>
+		<a href="https://partner.com/offer" rel="Sponsored nofollow">Offer</a>
+		<a href="https://m.facebook.com/example" title="Facebook"></a>
+		<a href="https://x.com/example" rel="me">@example</a>
+		<a href="https://partner.com/comment" rel="ugc">Commenter</a>
+		<a href="mailto:hello@example.com">Email</a>
+	</body></html>`)
+
+	links := ExtractLinks(doc)
+
+	want := []Link{
+		{URL: "https://www.example.com/about", Text: "About us"},
+		{URL: "https://example.com/contact", Text: "Contact"},
+		{URL: "https://partner.com/offer", Text: "Offer", Rel: []string{"sponsored", "nofollow"}, External: true, NoFollow: true, Sponsored: true},
+		{URL: "https://m.facebook.com/example", Text: "Facebook", External: true, Social: "facebook.com"},
+		{URL: "https://x.com/example", Text: "@example", Rel: []string{"me"}, External: true, Social: "twitter.com"},
+		{URL: "https://partner.com/comment", Text: "Commenter", Rel: []string{"ugc"}, External: true, UGC: true},
+	}
+
+	if len(links.Links) != len(want) {
+		t.Fatalf("ExtractLinks() = %+v, want %+v", links.Links, want)
+	}
+	for i, link := range links.Links {
+		if len(link.Rel) == 0 {
+			link.Rel = nil
+		}
+		if reflect.DeepEqual(link, want[i]) == false {
+			t.Errorf("ExtractLinks() link %d = %+v, want %+v", i, link, want[i])
+		}
+	}
+
+	wantSummary := LinkSummary{
+		Total: 6, Internal: 2, External: 4, NoFollow: 1, Sponsored: 1, UGC: 1,
+		Domains: map[string]int{"partner.com": 2, "m.facebook.com": 1, "x.com": 1},
+		Social:  map[string]int{"facebook.com": 1, "twitter.com": 1},
+	}
+	if reflect.DeepEqual(links.Summary, wantSummary) == false {
+		t.Errorf("ExtractLinks() summary = %+v, want %+v", links.Summary, wantSummary)
+	}
+}
+
+func TestSocialDomain(t *testing.T) {
+	tests := map[string]string{
+		"twitter.com":       "twitter.com",
+		"mobile.x.com":      "twitter.com",
+		"youtu.be":          "youtube.com",
+		"en-gb.fb.com":      "facebook.com",
+		"example.com":       "",
+		"notfacebook.com":   "",
+		"facebook.com.evil": "",
+	}
+
+	for host, want := range tests {
+		if got := socialDomain(host); got != want {
+			t.Errorf("socialDomain(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
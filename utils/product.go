@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Product holds the commerce details of a product page.
+type Product struct {
+	Price        *Money  `json:"price"`
+	Availability string  `json:"availability,omitempty"` // schema.org ItemAvailability, eg: InStock
+	Brand        string  `json:"brand,omitempty"`
+	SKU          string  `json:"sku,omitempty"`
+	Rating       *Rating `json:"rating,omitempty"`
+}
+
+// Rating is an aggregate rating, as given by the page.
+type Rating struct {
+	Value string `json:"value"`
+	Count string `json:"count"`
+	Best  string `json:"best"`
+}
+
+// The availability values used by Open Graph / Facebook product tags, mapped to schema.org ItemAvailability.
+var availabilities = map[string]string{
+	"instock":             "InStock",
+	"available":           "InStock",
+	"outofstock":          "OutOfStock",
+	"oos":                 "OutOfStock",
+	"soldout":             "SoldOut",
+	"preorder":            "PreOrder",
+	"pending":             "PreOrder",
+	"availablefororder":   "BackOrder",
+	"backorder":           "BackOrder",
+	"discontinued":        "Discontinued",
+	"limitedavailability": "LimitedAvailability",
+}
+
+/*
+ExtractProduct extracts the price, availability, brand, SKU and rating of a product
+from the structured data items (a `Product` with its `offers`, or an `Offer`), and
+the `product:*` / `og:*` product meta tags used by Facebook and Pinterest. The
+structured data takes precedence.
+
+The price is normalized with ParseDecimalPrice, as the prices in the structured data
+and the meta tags are machine-readable. Returns nil if no (valid) price is found -
+which is what tells a product page apart.
+*/
+func ExtractProduct(doc *goquery.Document, items []*Item) *Product {
+	product := new(Product)
+	offer := new(productOffer)
+
+	for _, item := range items {
+		if item.Is("Product") {
+			if nested := item.Item("offers"); nested != nil {
+				offer.apply(nested)
+			}
+
+			product.Brand = firstNonEmptyString(product.Brand, item.Text("brand"))
+			product.SKU = firstNonEmptyString(product.SKU, item.Text("sku"))
+			if product.Rating == nil {
+				product.Rating = ItemRating(item)
+			}
+		} else if item.Is("Offer") || item.Is("AggregateOffer") {
+			offer.apply(item)
+		}
+	}
+
+	if len(offer.price) == 0 {
+		offer.price = firstNonEmptyString(
+			metaContent(doc, "meta[property='product:price:amount']"),
+			metaContent(doc, "meta[property='og:price:amount']"),
+		)
+		offer.currency = firstNonEmptyString(
+			metaContent(doc, "meta[property='product:price:currency']"),
+			metaContent(doc, "meta[property='og:price:currency']"),
+		)
+	}
+
+	price, err := ParseDecimalPrice(offer.price, offer.currency)
+	if err != nil {
+		return nil
+	}
+	product.Price = price
+
+	product.Availability = NormalizeAvailability(firstNonEmptyString(
+		offer.availability,
+		metaContent(doc, "meta[property='product:availability']"),
+		metaContent(doc, "meta[property='og:availability']"),
+	))
+
+	product.Brand = firstNonEmptyString(product.Brand,
+		metaContent(doc, "meta[property='product:brand']"),
+		metaContent(doc, "meta[property='og:brand']"),
+	)
+	product.SKU = firstNonEmptyString(product.SKU,
+		metaContent(doc, "meta[property='product:retailer_item_id']"),
+		metaContent(doc, "meta[property='product:sku']"),
+	)
+
+	product.Brand = strings.TrimSpace(product.Brand)
+	product.SKU = strings.TrimSpace(product.SKU)
+
+	return product
+}
+
+// ItemRating returns the `aggregateRating` of the item, or nil if it has none.
+func ItemRating(item *Item) *Rating {
+	rating := item.Item("aggregateRating")
+	if rating == nil {
+		return nil
+	}
+
+	return &Rating{
+		Value: rating.Text("ratingValue"),
+		Count: firstNonEmptyString(rating.Text("ratingCount"), rating.Text("reviewCount")),
+		Best:  rating.Text("bestRating"),
+	}
+}
+
+/*
+NormalizeAvailability normalizes an availability value - either a schema.org
+ItemAvailability (eg: `http://schema.org/InStock`) or one of the values used by the
+product meta tags (eg: `in stock`, `oos`) - to the schema.org name, eg: `InStock`.
+*/
+func NormalizeAvailability(value string) string {
+	name := SchemaTypeName(strings.TrimSpace(value))
+
+	key := strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(name))
+	if availability, ok := availabilities[key]; ok == true {
+		return availability
+	}
+
+	return name
+}
+
+// productOffer is the (raw) price and availability of the first offer that has a price.
+type productOffer struct {
+	price        string
+	currency     string
+	availability string
+}
+
+func (offer *productOffer) apply(item *Item) {
+	if len(offer.price) > 0 {
+		return
+	}
+
+	// The price can also be given as a PriceSpecification.
+	priced := item
+	if spec := item.Item("priceSpecification"); spec != nil && len(firstNonEmptyString(item.Text("price"), item.Text("lowPrice"))) == 0 {
+		priced = spec
+	}
+
+	if price := firstNonEmptyString(priced.Text("price"), priced.Text("lowPrice")); len(price) > 0 {
+		offer.price = price
+		offer.currency = priced.Text("priceCurrency")
+		offer.availability = item.Text("availability")
+
+		// A range, for AggregateOffers.
+		if highPrice := priced.Text("highPrice"); len(highPrice) > 0 && price == priced.Text("lowPrice") {
+			offer.price += " - " + highPrice
+		}
+	}
+}
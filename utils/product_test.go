@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractProduct(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want *Product
+	}{
+		{
+			"microdata",
+			`<div itemscope itemtype="http://schema.org/Product">
+				<span itemprop="name">A Product</span>
+				<span itemprop="brand" itemscope itemtype="http://schema.org/Brand"><span itemprop="name">Brand</span></span>
+				<meta itemprop="sku" content=" SKU1 ">
+				<div itemprop="aggregateRating" itemscope itemtype="http://schema.org/AggregateRating">
+					<span itemprop="ratingValue">4.5</span> (<span itemprop="reviewCount">10</span>)
+				</div>
+				<div itemprop="offers" itemscope itemtype="http://schema.org/Offer">
+					<meta itemprop="price" content="12.50"><meta itemprop="priceCurrency" content="USD">
+					<link itemprop="availability" href="http://schema.org/InStock">
+				</div>
+			</div>`,
+			&Product{Price: &Money{Amount: "12.50", Currency: "USD"}, Availability: "InStock", Brand: "Brand", SKU: "SKU1", Rating: &Rating{Value: "4.5", Count: "10"}},
+		},
+		{
+			"price specification",
+			`<div itemscope itemtype="http://schema.org/Offer">
+				<div itemprop="priceSpecification" itemscope itemtype="http://schema.org/UnitPriceSpecification">
+					<meta itemprop="price" content="5"><meta itemprop="priceCurrency" content="EUR">
+				</div>
+				<meta itemprop="availability" content="https://schema.org/PreOrder">
+			</div>`,
+			&Product{Price: &Money{Amount: "5", Currency: "EUR"}, Availability: "PreOrder"},
+		},
+		{
+			"meta tags",
+			`<meta property="product:price:amount" content="99.99">
+			<meta property="product:price:currency" content="GBP">
+			<meta property="product:availability" content="out of stock">
+			<meta property="product:brand" content="Brand">
+			<meta property="product:retailer_item_id" content="SKU2">`,
+			&Product{Price: &Money{Amount: "99.99", Currency: "GBP"}, Availability: "OutOfStock", Brand: "Brand", SKU: "SKU2"},
+		},
+		{"no price", `<meta property="product:brand" content="Brand">`, nil},
+	}
+
+	for _, test := range tests {
+		doc := newTestDocument(t, "https://example.com/products/1", "<html><body>"+test.html+"</body></html>")
+
+		if product := ExtractProduct(doc, ExtractMicrodata(doc)); reflect.DeepEqual(product, test.want) == false {
+			t.Errorf("%s: ExtractProduct() = %+v, want %+v", test.name, product, test.want)
+		}
+	}
+}
+
+func TestNormalizeAvailability(t *testing.T) {
+	tests := map[string]string{
+		"http://schema.org/InStock":  "InStock",
+		"https://schema.org/SoldOut": "SoldOut",
+		"in stock":                   "InStock",
+		"OOS":                        "OutOfStock",
+		"available for order":        "BackOrder",
+		"pre-order":                  "PreOrder",
+		"schema:LimitedAvailability": "LimitedAvailability",
+		"OnlineOnly":                 "OnlineOnly",
+		"":                           "",
+	}
+
+	for value, want := range tests {
+		if availability := NormalizeAvailability(value); availability != want {
+			t.Errorf("NormalizeAvailability(%q) = %q, want %q", value, availability, want)
+		}
+	}
+}
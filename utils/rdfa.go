@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/net/html"
+)
+
+/*
+ExtractRDFa extracts the items expressed using RDFa Lite (https://www.w3.org/TR/rdfa-lite/),
+ie, using the `vocab`, `typeof`, `property`, `resource` and `prefix` attributes.
+
+Only top-level items (ie, `typeof` elements which are not themselves the value of
+a `property`) are returned. Nested items are available as property values.
+
+Types are resolved against the vocabulary in scope. Property names are returned as
+written, except that the schema.org vocabulary is stripped (`schema:name` => `name`)
+so that the items can be handled just like Microdata items.
+
+Properties outside of any typed element (eg: Open Graph `<meta property>` tags) are
+ignored.
+*/
+func ExtractRDFa(doc *goquery.Document) []*Item {
+	parser := &rdfaParser{doc: doc, items: []*Item{}}
+
+	for _, node := range doc.Nodes {
+		parser.walk(node, "", map[string]string{}, nil)
+	}
+
+	return parser.items
+}
+
+type rdfaParser struct {
+	doc   *goquery.Document
+	items []*Item
+}
+
+func (parser *rdfaParser) walk(node *html.Node, vocab string, prefixes map[string]string, current *Item) {
+	if node.Type == html.ElementNode {
+		if value, exists := nodeAttr(node, "vocab"); exists == true {
+			vocab = strings.TrimSpace(value)
+		}
+
+		if value, exists := nodeAttr(node, "prefix"); exists == true {
+			prefixes = parseRDFaPrefixes(value, prefixes)
+		}
+
+		property, _ := nodeAttr(node, "property")
+		names := strings.Fields(property)
+
+		if typeOf, isTyped := nodeAttr(node, "typeof"); isTyped == true {
+			item := newItem()
+
+			for _, term := range strings.Fields(typeOf) {
+				item.Type = append(item.Type, resolveRDFaTerm(term, vocab, prefixes))
+			}
+
+			if resource, exists := nodeAttr(node, "resource"); exists == true {
+				item.ID = resolveReference(parser.doc, resource)
+			}
+
+			if current != nil && len(names) > 0 {
+				for _, name := range names {
+					current.addProperty(rdfaPropertyName(name, vocab), item)
+				}
+			} else {
+				parser.items = append(parser.items, item)
+			}
+
+			current = item
+
+		} else if current != nil && len(names) > 0 {
+			value := parser.value(node)
+
+			for _, name := range names {
+				current.addProperty(rdfaPropertyName(name, vocab), value)
+			}
+		}
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		parser.walk(child, vocab, prefixes, current)
+	}
+}
+
+// value returns the literal or URL value of a property element.
+func (parser *rdfaParser) value(element *html.Node) string {
+	if content, exists := nodeAttr(element, "content"); exists == true {
+		return content
+	}
+
+	for _, attr := range []string{"resource", "href", "src"} {
+		if value, exists := nodeAttr(element, attr); exists == true {
+			return resolveReference(parser.doc, value)
+		}
+	}
+
+	if element.Data == "time" {
+		if dateTime, exists := nodeAttr(element, "datetime"); exists == true {
+			return dateTime
+		}
+	}
+
+	return nodeText(element)
+}
+
+// parseRDFaPrefixes parses a `prefix` attribute (eg: "dc: http://purl.org/dc/terms/ schema: http://schema.org/").
+func parseRDFaPrefixes(value string, inherited map[string]string) map[string]string {
+	prefixes := make(map[string]string)
+	for name, iri := range inherited {
+		prefixes[name] = iri
+	}
+
+	fields := strings.Fields(value)
+	for i := 0; i+1 < len(fields); i += 2 {
+		if strings.HasSuffix(fields[i], ":") {
+			prefixes[strings.TrimSuffix(fields[i], ":")] = fields[i+1]
+		}
+	}
+
+	return prefixes
+}
+
+// resolveRDFaTerm expands a term or a prefixed name to a full IRI where possible.
+func resolveRDFaTerm(term string, vocab string, prefixes map[string]string) string {
+	if index := strings.Index(term, ":"); index > 0 {
+		if iri, ok := prefixes[term[:index]]; ok == true {
+			return iri + term[index+1:]
+		}
+
+		return term
+	}
+
+	return vocab + term
+}
+
+func rdfaPropertyName(name string, vocab string) string {
+	for _, schemaVocab := range []string{"schema:", "http://schema.org/", "https://schema.org/"} {
+		if strings.HasPrefix(name, schemaVocab) {
+			return strings.TrimPrefix(name, schemaVocab)
+		}
+	}
+
+	if len(vocab) > 0 && strings.HasPrefix(name, vocab) {
+		return strings.TrimPrefix(name, vocab)
+	}
+
+	return name
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractRDFa(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/articles/1", `<html><head>
+		<meta property="og:title" content="Ignored">
+	</head><body>
+		<article vocab="http://schema.org/" typeof="Article" resource="#article">
+			<h1 property="headline">A Headline</h1>
+			<a property="url" href="1">Permalink</a>
+			<time property="datePublished" datetime="2015-03-01">1 March</time>
+			<div property="author" typeof="Person">
+				<span property="name">Someone</span>
+			</div>
+			<meta property="schema:inLanguage" content="en">
+		</article>
+		<div prefix="dc: http://purl.org/dc/terms/" typeof="dc:BibliographicResource">
+			<span property="dc:title">A Title</span>
+		</div>
+	</body></html>`)
+
+	items := ExtractRDFa(doc)
+	if len(items) != 2 {
+		t.Fatalf("ExtractRDFa() returned %d items, want 2", len(items))
+	}
+
+	article := items[0]
+	if reflect.DeepEqual(article.Type, []string{"http://schema.org/Article"}) == false || article.Is("Article") == false {
+		t.Errorf("Article item has the type %v", article.Type)
+	}
+	if article.ID != "https://example.com/articles/1#article" {
+		t.Errorf("Article item has the ID %q", article.ID)
+	}
+
+	properties := map[string]string{
+		"headline":      "A Headline",
+		"url":           "https://example.com/articles/1",
+		"datePublished": "2015-03-01",
+		"inLanguage":    "en",
+		"author":        "Someone",
+	}
+	for name, want := range properties {
+		if value := article.Text(name); value != want {
+			t.Errorf("Article property %q = %q, want %q", name, value, want)
+		}
+	}
+
+	resource := items[1]
+	if reflect.DeepEqual(resource.Type, []string{"http://purl.org/dc/terms/BibliographicResource"}) == false {
+		t.Errorf("Dublin Core item has the type %v", resource.Type)
+	}
+	if title := resource.String("dc:title"); title != "A Title" {
+		t.Errorf("Dublin Core item title = %q, want %q", title, "A Title")
+	}
+}
+
+func TestResolveRDFaTerm(t *testing.T) {
+	prefixes := parseRDFaPrefixes("dc: http://purl.org/dc/terms/ og: http://ogp.me/ns#", map[string]string{"schema": "http://schema.org/"})
+
+	tests := []struct {
+		term  string
+		vocab string
+		want  string
+	}{
+		{"Article", "http://schema.org/", "http://schema.org/Article"},
+		{"dc:Agent", "", "http://purl.org/dc/terms/Agent"},
+		{"schema:Person", "", "http://schema.org/Person"},
+		{"unknown:Thing", "http://schema.org/", "unknown:Thing"},
+		{"Thing", "", "Thing"},
+	}
+
+	for _, test := range tests {
+		if iri := resolveRDFaTerm(test.term, test.vocab, prefixes); iri != test.want {
+			t.Errorf("resolveRDFaTerm(%q, %q) = %q, want %q", test.term, test.vocab, iri, test.want)
+		}
+	}
+}
+
+func TestExtractDublinCore(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/", `<html><head>
+		<meta name="DC.Title" content=" A Title ">
+		<meta name="DC.creator" content="One">
+		<meta name="dc.creator" content="Two">
+		<meta name="DCTERMS.identifier" content="urn:isbn:123">
+		<meta name="DC.Date.Issued" content="2015-03-01">
+		<meta name="DCTERMS.dateCopyrighted" content="2015">
+		<meta property="dcterms:language" content="en">
+		<meta name="DC.subject" content="">
+		<meta name="description" content="Not Dublin Core">
+	</head><body></body></html>`)
+
+	want := DublinCore{
+		"title":           {"A Title"},
+		"creator":         {"One", "Two"},
+		"identifier":      {"urn:isbn:123"},
+		"date.issued":     {"2015-03-01"},
+		"datecopyrighted": {"2015"},
+		"language":        {"en"},
+	}
+
+	dc := ExtractDublinCore(doc)
+	if reflect.DeepEqual(dc, want) == false {
+		t.Errorf("ExtractDublinCore() = %v, want %v", dc, want)
+	}
+
+	if value := dc.First("date.created", "date.issued", "date"); value != "2015-03-01" {
+		t.Errorf("First() = %q, want %q", value, "2015-03-01")
+	}
+}
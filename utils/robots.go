@@ -0,0 +1,187 @@
+package utils
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+/*
+Robots are the indexing and snippet directives of a page, from the `robots` and
+`googlebot` meta tags and the `X-Robots-Tag` header
+(https://developers.google.com/search/docs/crawling-indexing/robots-meta-tag), along
+with the content rating.
+*/
+type Robots struct {
+	NoIndex      bool `json:"noindex,omitempty"`
+	NoFollow     bool `json:"nofollow,omitempty"`
+	NoArchive    bool `json:"noarchive,omitempty"`
+	NoSnippet    bool `json:"nosnippet,omitempty"`
+	NoImageIndex bool `json:"noimageindex,omitempty"`
+	NoTranslate  bool `json:"notranslate,omitempty"`
+
+	// Maximum length of a text snippet, -1 for no limit. nil if not given.
+	MaxSnippet *int `json:"maxSnippet,omitempty"`
+	// Maximum size of an image preview: none, standard or large.
+	MaxImagePreview string `json:"maxImagePreview,omitempty"`
+	// Maximum duration (in seconds) of a video preview, -1 for no limit. nil if not given.
+	MaxVideoPreview *int `json:"maxVideoPreview,omitempty"`
+
+	Rating string `json:"rating,omitempty"` // As declared, eg: "adult", "general"
+	Adult  bool   `json:"adult"`
+}
+
+// Ratings (lowercased) that mark adult content, including the RTA label (http://www.rtalabel.org).
+var adultRatings = map[string]bool{
+	"adult": true, "mature": true, "restricted": true, "rta-5042-1996-1400-1577-rta": true,
+}
+
+/*
+ExtractRobots extracts the robots directives from the `robots` and `googlebot` meta
+tags and the `X-Robots-Tag` headers (except those for other user agents, eg:
+`otherbot: noindex`). The most restrictive of the directives wins. The content rating
+is taken from the `rating` meta tag, or `adult` (eg: `<meta name="adult" content="true">`).
+
+Returns nil if the page has none of these.
+*/
+func ExtractRobots(doc *goquery.Document, header http.Header) *Robots {
+	robots := new(Robots)
+	found := false
+
+	doc.Find("meta[name][content]").Each(func(_ int, s *goquery.Selection) {
+		content := strings.TrimSpace(attr(s, "content"))
+
+		switch strings.ToLower(attr(s, "name")) {
+		case "robots", "googlebot":
+			found = robots.apply(content) || found
+
+		case "rating":
+			if len(content) > 0 && len(robots.Rating) == 0 {
+				robots.Rating = content
+				robots.Adult = robots.Adult || adultRatings[strings.ToLower(content)]
+				found = true
+			}
+
+		case "adult":
+			if adult := strings.ToLower(content); len(adult) > 0 {
+				robots.Adult = robots.Adult || adult == "true" || adult == "yes" || adult == "1"
+				found = true
+			}
+		}
+	})
+
+	for _, value := range header[http.CanonicalHeaderKey("X-Robots-Tag")] {
+		// The value may be for a user agent, eg: "googlebot: noindex, nofollow"
+		if index := strings.Index(value, ":"); index > 0 {
+			agent := strings.ToLower(strings.TrimSpace(value[:index]))
+			if strings.ContainsAny(agent, " ,") == false && robotsDirective(agent) == false {
+				if agent != "googlebot" {
+					continue
+				}
+				value = value[index+1:]
+			}
+		}
+
+		found = robots.apply(value) || found
+	}
+
+	if found == false {
+		return nil
+	}
+
+	return robots
+}
+
+// apply applies the comma separated directives, eg: "noindex, max-snippet:50". Returns true if any were applied.
+func (robots *Robots) apply(directives string) bool {
+	applied := false
+
+	for _, directive := range strings.Split(strings.ToLower(directives), ",") {
+		name, value := strings.TrimSpace(directive), ""
+		if index := strings.Index(name, ":"); index > 0 {
+			name, value = strings.TrimSpace(name[:index]), strings.TrimSpace(name[index+1:])
+		}
+
+		switch name {
+		case "noindex":
+			robots.NoIndex = true
+		case "nofollow":
+			robots.NoFollow = true
+		case "none":
+			robots.NoIndex, robots.NoFollow = true, true
+		case "noarchive", "nocache":
+			robots.NoArchive = true
+		case "nosnippet":
+			robots.NoSnippet = true
+		case "noimageindex":
+			robots.NoImageIndex = true
+		case "notranslate":
+			robots.NoTranslate = true
+		case "max-snippet":
+			robots.MaxSnippet = minLimit(robots.MaxSnippet, value)
+		case "max-video-preview":
+			robots.MaxVideoPreview = minLimit(robots.MaxVideoPreview, value)
+		case "max-image-preview":
+			if rank := imagePreviewRank(value); rank >= 0 && (len(robots.MaxImagePreview) == 0 || rank < imagePreviewRank(robots.MaxImagePreview)) {
+				robots.MaxImagePreview = value
+			}
+		case "all", "index", "follow":
+			// Defaults
+		default:
+			continue
+		}
+
+		applied = true
+	}
+
+	return applied
+}
+
+// HidesSnippet checks if text snippets (eg: the description) must not be shown.
+func (robots *Robots) HidesSnippet() bool {
+	return robots.NoSnippet == true || (robots.MaxSnippet != nil && *robots.MaxSnippet == 0)
+}
+
+// HidesImage checks if image previews (eg: the thumbnail) must not be shown.
+func (robots *Robots) HidesImage() bool {
+	return robots.NoSnippet == true || robots.MaxImagePreview == "none"
+}
+
+// minLimit returns the more restrictive of the limits, where -1 is no limit.
+func minLimit(limit *int, value string) *int {
+	number, err := strconv.Atoi(value)
+	if err != nil || number < -1 {
+		return limit
+	}
+
+	if limit == nil || *limit == -1 || (number != -1 && number < *limit) {
+		return &number
+	}
+
+	return limit
+}
+
+func imagePreviewRank(value string) int {
+	switch value {
+	case "none":
+		return 0
+	case "standard":
+		return 1
+	case "large":
+		return 2
+	}
+
+	return -1
+}
+
+// robotsDirective checks if the name is a known directive (as opposed to a user agent).
+func robotsDirective(name string) bool {
+	switch name {
+	case "max-snippet", "max-image-preview", "max-video-preview", "unavailable_after":
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestExtractRobots(t *testing.T) {
+	limit := func(value int) *int { return &value }
+
+	tests := []struct {
+		meta   string
+		header http.Header
+		want   *Robots
+	}{
+		{``, nil, nil},
+		{`<meta name="robots" content="index, follow">`, nil, &Robots{}},
+		{`<meta name="robots" content="noindex, nofollow">`, nil, &Robots{NoIndex: true, NoFollow: true}},
+		{`<meta name="ROBOTS" content="none">`, nil, &Robots{NoIndex: true, NoFollow: true}},
+		{`<meta name="googlebot" content="nosnippet, noarchive">`, nil, &Robots{NoSnippet: true, NoArchive: true}},
+		{
+			`<meta name="robots" content="max-snippet:50, max-image-preview:large"><meta name="googlebot" content="max-snippet:20, max-image-preview:none">`,
+			nil,
+			&Robots{MaxSnippet: limit(20), MaxImagePreview: "none"},
+		},
+		{`<meta name="robots" content="max-snippet:-1, max-video-preview:10">`, nil, &Robots{MaxSnippet: limit(-1), MaxVideoPreview: limit(10)}},
+		{`<meta name="robots" content="max-snippet:-1"><meta name="googlebot" content="max-snippet:30">`, nil, &Robots{MaxSnippet: limit(30)}},
+		{`<meta name="otherbot" content="noindex">`, nil, nil},
+		{``, http.Header{"X-Robots-Tag": {"noindex", "googlebot: nosnippet", "otherbot: noarchive"}}, &Robots{NoIndex: true, NoSnippet: true}},
+		{``, http.Header{"X-Robots-Tag": {"max-snippet: 10"}}, &Robots{MaxSnippet: limit(10)}},
+		{`<meta name="rating" content="adult">`, nil, &Robots{Rating: "adult", Adult: true}},
+		{`<meta name="rating" content="RTA-5042-1996-1400-1577-RTA">`, nil, &Robots{Rating: "RTA-5042-1996-1400-1577-RTA", Adult: true}},
+		{`<meta name="rating" content="general">`, nil, &Robots{Rating: "general"}},
+		{`<meta name="adult" content="yes">`, nil, &Robots{Adult: true}},
+	}
+
+	for _, test := range tests {
+		doc := newTestDocument(t, "https://example.com/", "<html><head>"+test.meta+"</head><body></body></html>")
+
+		if robots := ExtractRobots(doc, test.header); reflect.DeepEqual(robots, test.want) == false {
+			t.Errorf("ExtractRobots(%q, %v) = %+v, want %+v", test.meta, test.header, robots, test.want)
+		}
+	}
+}
+
+func TestRobotsHides(t *testing.T) {
+	limit := func(value int) *int { return &value }
+
+	tests := []struct {
+		robots  Robots
+		snippet bool
+		image   bool
+	}{
+		{Robots{}, false, false},
+		{Robots{NoSnippet: true}, true, true},
+		{Robots{MaxSnippet: limit(0)}, true, false},
+		{Robots{MaxSnippet: limit(-1)}, false, false},
+		{Robots{MaxImagePreview: "none"}, false, true},
+		{Robots{MaxImagePreview: "standard", NoIndex: true}, false, false},
+	}
+
+	for _, test := range tests {
+		if snippet, image := test.robots.HidesSnippet(), test.robots.HidesImage(); snippet != test.snippet || image != test.image {
+			t.Errorf("%+v hides snippet: %v, image: %v, want %v, %v", test.robots, snippet, image, test.snippet, test.image)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+/*
+Item represents a single typed structured data item (eg: a schema.org Product)
+found in a document, irrespective of the syntax (Microdata, JSON-LD, etc) it was
+expressed in.
+
+Property values are either strings or nested *Item values, in document order.
+*/
+type Item struct {
+	Type       []string                 `json:"type"`
+	ID         string                   `json:"id,omitempty"`
+	Properties map[string][]interface{} `json:"properties"`
+
+	// Set for the nodes that are the value of a reference (eg: a JSON-LD `{"@id": ...}`).
+	reference bool
+}
+
+func newItem() *Item {
+	item := new(Item)
+	item.Properties = make(map[string][]interface{})
+
+	return item
+}
+
+/*
+MarshalJSON marshals the item. A node that is the value of a reference is marshaled
+as just its type and ID (as the reference), since the node itself is marshaled where
+it is defined - otherwise nodes referenced over and over would be repeated each time.
+*/
+func (item *Item) MarshalJSON() ([]byte, error) {
+	if item.reference == true {
+		return json.Marshal(struct {
+			Type []string `json:"type"`
+			ID   string   `json:"id,omitempty"`
+		}{item.Type, item.ID})
+	}
+
+	type plainItem Item
+	return json.Marshal((*plainItem)(item))
+}
+
+func (item *Item) addProperty(name string, value interface{}) {
+	item.Properties[name] = append(item.Properties[name], value)
+}
+
+/*
+Is checks if the item is of the given type. The type can either be the full type
+URL (eg: `http://schema.org/Product`) or just the type name (eg: `Product`), in
+which case the vocabulary is ignored while matching.
+*/
+func (item *Item) Is(typeName string) bool {
+	for _, itemType := range item.Type {
+		if itemType == typeName || SchemaTypeName(itemType) == typeName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the first string value of the given property, if any.
+func (item *Item) String(name string) string {
+	for _, value := range item.Properties[name] {
+		if str, ok := value.(string); ok == true {
+			return strings.TrimSpace(str)
+		}
+	}
+
+	return ""
+}
+
+// Item returns the first nested item value of the given property, if any.
+func (item *Item) Item(name string) *Item {
+	for _, value := range item.Properties[name] {
+		if nested, ok := value.(*Item); ok == true {
+			return nested
+		}
+	}
+
+	return nil
+}
+
+/*
+Text returns the first value of the given property as text. Nested items are
+represented by their `name` property, which is how most schema.org consumers treat
+things like an `author` that is given as a Person.
+*/
+func (item *Item) Text(name string) string {
+	for _, value := range item.Properties[name] {
+		switch v := value.(type) {
+		case string:
+			if text := strings.TrimSpace(v); len(text) > 0 {
+				return text
+			}
+		case *Item:
+			if text := v.String("name"); len(text) > 0 {
+				return text
+			}
+		}
+	}
+
+	return ""
+}
+
+// SchemaTypeName strips the vocabulary from a type URL, eg: `http://schema.org/Product` => `Product`.
+func SchemaTypeName(itemType string) string {
+	if index := strings.LastIndexAny(itemType, "/#:"); index >= 0 {
+		return itemType[index+1:]
+	}
+
+	return itemType
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Ellipsis appended to truncated text, unless another one is given.
+const DefaultEllipsis = "…"
+
+// Matches text that contains markup - a tag or a comment.
+var markup = regexp.MustCompile(`<(?:[a-zA-Z][^>]*|/[a-zA-Z][^>]*|!--[\s\S]*?--)>`)
+
+/*
+SanitizeText cleans up text that may contain HTML (eg: descriptions from meta tags
+or provider APIs): strips the tags (and the contents of scripts and styles), decodes
+the entities, normalizes to Unicode NFC, removes the control characters and collapses
+the whitespace.
+*/
+func SanitizeText(text string) string {
+	if markup.MatchString(text) {
+		text = stripTags(text)
+	} else {
+		text = html.UnescapeString(text)
+	}
+
+	text = norm.NFC.String(text)
+
+	text = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return ' '
+		}
+		if unicode.IsControl(r) || r == unicode.ReplacementChar {
+			return -1
+		}
+		return r
+	}, text)
+
+	return collapseWhitespace(text)
+}
+
+/*
+TruncateText truncates the text to at most maxLength characters (runes, including
+the ellipsis) at a word boundary, appending the ellipsis (DefaultEllipsis if empty).
+Words longer than the whole length are cut. Text within the length is returned as is.
+*/
+func TruncateText(text string, maxLength int, ellipsis string) string {
+	runes := []rune(text)
+	if maxLength <= 0 || len(runes) <= maxLength {
+		return text
+	}
+
+	if len(ellipsis) == 0 {
+		ellipsis = DefaultEllipsis
+	}
+
+	limit := maxLength - len([]rune(ellipsis))
+	if limit <= 0 {
+		return string(runes[:maxLength])
+	}
+
+	truncated := runes[:limit]
+
+	// Cut at the last word boundary, unless the text is a single long word.
+	if unicode.IsSpace(runes[limit]) == false {
+		for i := len(truncated) - 1; i > 0; i-- {
+			if unicode.IsSpace(truncated[i]) {
+				truncated = truncated[:i]
+				break
+			}
+		}
+	}
+
+	return strings.TrimRightFunc(string(truncated), func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(",;:-–—", r)
+	}) + ellipsis
+}
+
+// stripTags returns the text content of the HTML fragment, with the entities decoded.
+func stripTags(fragment string) string {
+	var text strings.Builder
+
+	tokenizer := html.NewTokenizer(strings.NewReader(fragment))
+	skipping := ""
+
+	for {
+		switch tokenType := tokenizer.Next(); tokenType {
+		case html.ErrorToken:
+			// End of the fragment (or invalid markup)
+			return text.String()
+
+		case html.TextToken:
+			if len(skipping) == 0 {
+				text.Write(tokenizer.Text())
+			}
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			if tag := string(name); (tag == "script" || tag == "style") && tokenType == html.StartTagToken && len(skipping) == 0 {
+				skipping = tag
+			} else if blockTags[tag] == true {
+				text.WriteString(" ")
+			}
+
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if tag := string(name); tag == skipping {
+				skipping = ""
+			} else if blockTags[tag] == true {
+				text.WriteString(" ")
+			}
+		}
+	}
+}
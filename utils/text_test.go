@@ -0,0 +1,49 @@
+package utils
+
+import "testing"
+
+func TestSanitizeText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Plain   text\n\twith  whitespace ", "Plain text with whitespace"},
+		{"Tom &amp; Jerry &mdash; &lt;3", "Tom & Jerry — <3"},
+		{"<p>Some <b>bold</b> text</p><script>alert(1)</script><style>p {}</style>", "Some bold text"},
+		{"Text <!-- a comment --> here", "Text here"},
+		{"1 < 2 and 3 > 2", "1 < 2 and 3 > 2"},
+		{"Café", "Café"},
+		{"Control\u0000 chars\u0007�", "Control chars"},
+	}
+
+	for _, test := range tests {
+		if sanitized := SanitizeText(test.text); sanitized != test.want {
+			t.Errorf("SanitizeText(%q) = %q, want %q", test.text, sanitized, test.want)
+		}
+	}
+}
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		text      string
+		maxLength int
+		ellipsis  string
+		want      string
+	}{
+		{"Short text", 20, "", "Short text"},
+		{"Short text", 0, "", "Short text"},
+		{"The quick brown fox jumps", 16, "", "The quick brown…"},
+		{"The quick brown fox jumps", 15, "", "The quick…"},
+		{"The quick, brown fox", 13, "...", "The quick..."},
+		{"The quick, brown fox", 12, "...", "The..."},
+		{"Supercalifragilistic", 10, "", "Supercali…"},
+		{"Ünïcödé téxt here", 12, "", "Ünïcödé…"},
+		{"Anything", 2, "...", "An"},
+	}
+
+	for _, test := range tests {
+		if truncated := TruncateText(test.text, test.maxLength, test.ellipsis); truncated != test.want {
+			t.Errorf("TruncateText(%q, %d, %q) = %q, want %q", test.text, test.maxLength, test.ellipsis, truncated, test.want)
+		}
+	}
+}
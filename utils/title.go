@@ -0,0 +1,182 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// Separators used between the parts of a title, eg: "Some Headline | The Publisher".
+var titleSeparators = []string{" | ", " - ", " – ", " — ", " :: ", " » ", " « ", " · ", " • ", " / ", " ~ "}
+
+// Maximum number of words in a part of the title that is taken to be the site name, when no site name is declared.
+const maxSiteNameWords = 4
+
+/*
+ExtractSiteName extracts the name of the website from `og:site_name`,
+`application-name` and `apple-mobile-web-app-title` (in that order).
+*/
+func ExtractSiteName(doc *goquery.Document) string {
+	return collapseWhitespace(firstNonEmptyString(
+		metaContent(doc, "meta[property='og:site_name']"),
+		metaContent(doc, "meta[name='application-name']"),
+		metaContent(doc, "meta[name='apple-mobile-web-app-title']"),
+	))
+}
+
+/*
+CleanTitle cleans up a page title - collapsing the whitespace - and strips the site
+name prefix or suffix, eg: "Some Headline | The Publisher - Section" => "Some Headline".
+
+The title is split into parts at the common separators. The site name is the part
+matching the declared site name (if given) or the host name, and the title is what's
+left on the longer side of it. Without either, the site name is guessed from the
+title alone: the short, name-like parts at the end (or else at the start) of the
+title are taken to be the site name and its sections, and are all stripped.
+
+Returns the cleaned title and the site name - the declared one if given, or the one
+detected in the title (the first of the guessed parts, eg: "The Publisher").
+*/
+func CleanTitle(rawTitle string, siteName string, host string) (string, string) {
+	title := collapseWhitespace(rawTitle)
+	siteName = collapseWhitespace(siteName)
+
+	parts, separators := splitTitle(title)
+	if len(parts) < 2 {
+		return title, siteName
+	}
+
+	// The site name (and sections) are parts[siteStart:siteEnd+1].
+	siteStart, siteEnd := -1, -1
+	for i, part := range parts {
+		if (len(siteName) > 0 && sameName(part, siteName)) || matchesHost(part, host) {
+			siteStart, siteEnd = i, i
+			break
+		}
+	}
+
+	// Guess from the title alone - the site name is a short name, usually at the end.
+	if siteStart < 0 && len(siteName) == 0 {
+		last := len(parts) - 1
+		firstWords, lastWords := len(strings.Fields(parts[0])), len(strings.Fields(parts[last]))
+
+		if looksLikeSiteName(parts[last]) && lastWords <= firstWords {
+			// eg: "Some Headline | The Publisher - Section" - the title is the first part.
+			siteStart, siteEnd = last, last
+			for siteStart > 1 && looksLikeSiteName(parts[siteStart-1]) && len(strings.Fields(parts[siteStart-1])) <= firstWords {
+				siteStart--
+			}
+		} else if looksLikeSiteName(parts[0]) && firstWords < lastWords {
+			// eg: "The Publisher - Section: Some Long Headline" - the title is the last part.
+			siteStart, siteEnd = 0, 0
+			for siteEnd < last-1 && looksLikeSiteName(parts[siteEnd+1]) && len(strings.Fields(parts[siteEnd+1])) < lastWords {
+				siteEnd++
+			}
+		}
+	}
+
+	if siteStart < 0 {
+		return title, siteName
+	}
+
+	if len(siteName) == 0 {
+		siteName = parts[siteStart]
+	}
+
+	// The title is on the longer side of the site name.
+	before := joinTitle(parts[:siteStart], separators[:siteStart])
+	after := joinTitle(parts[siteEnd+1:], separators[siteEnd+1:])
+
+	if len(after) > len(before) {
+		// eg: "Publisher: Section - Headline" - the sections are closer to the site name.
+		return after, siteName
+	}
+
+	return before, siteName
+}
+
+// splitTitle splits the title into its parts at the separators, returning the parts and the separator before each part.
+func splitTitle(title string) ([]string, []string) {
+	parts := []string{}
+	separators := []string{""}
+
+	for {
+		index, separator := -1, ""
+		for _, candidate := range titleSeparators {
+			if i := strings.Index(title, candidate); i > 0 && (index < 0 || i < index) {
+				index, separator = i, candidate
+			}
+		}
+
+		if index < 0 {
+			break
+		}
+
+		parts = append(parts, title[:index])
+		separators = append(separators, separator)
+		title = title[index+len(separator):]
+	}
+
+	return append(parts, title), separators
+}
+
+// joinTitle joins the parts back with the separators before them (except the first).
+func joinTitle(parts []string, separators []string) string {
+	joined := ""
+	for i, part := range parts {
+		if i > 0 {
+			joined += separators[i]
+		}
+		joined += part
+	}
+
+	return strings.TrimSpace(joined)
+}
+
+// looksLikeSiteName checks if the part of the title could be a name - a few words, each capitalized (eg: "The Publisher").
+func looksLikeSiteName(part string) bool {
+	words := strings.Fields(part)
+	if len(words) > maxSiteNameWords {
+		return false
+	}
+
+	for _, word := range words {
+		if first := []rune(word)[0]; unicode.IsLower(first) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sameName compares names ignoring case, spaces and punctuation, eg: "The Verge" and "the-verge".
+func sameName(a string, b string) bool {
+	a, b = nameKey(a), nameKey(b)
+	return len(a) > 0 && a == b
+}
+
+// matchesHost checks if the part of the title is the name of the host, eg: "Example.com" or "Example" for www.example.com.
+func matchesHost(part string, host string) bool {
+	host = strings.TrimPrefix(strings.ToLower(strings.Split(host, ":")[0]), "www.")
+	labels := strings.Split(host, ".")
+	if len(host) == 0 || len(labels) < 2 {
+		return false
+	}
+
+	key := nameKey(part)
+	return key == nameKey(host) || key == nameKey(labels[len(labels)-2])
+}
+
+func nameKey(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+func collapseWhitespace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
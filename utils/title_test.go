@@ -0,0 +1,34 @@
+package utils
+
+import "testing"
+
+func TestCleanTitle(t *testing.T) {
+	tests := []struct {
+		rawTitle string
+		siteName string
+		host     string
+		title    string
+		site     string
+	}{
+		{"Some Headline | The Publisher - Section", "The Publisher", "www.publisher.com", "Some Headline", "The Publisher"},
+		{"Some Headline | The Publisher - Section", "", "", "Some Headline", "The Publisher"},
+		{"  Some Headline\n  - Example  ", "", "www.example.com", "Some Headline", "Example"},
+		{"Example.com - A longer headline about things", "", "example.com", "A longer headline about things", "Example.com"},
+		{"Example - A long headline about things", "", "", "A long headline about things", "Example"},
+		{"GitHub - owner/repo: A description here", "", "github.com", "owner/repo: A description here", "GitHub"},
+		{"A Headline | the-verge", "The Verge", "", "A Headline", "The Verge"},
+		{"Breaking News Today Here | CNN", "", "", "Breaking News Today Here", "CNN"},
+		{"X | Site", "", "", "X", "Site"},
+		{"Apples - and - oranges", "", "", "Apples - and - oranges", ""},
+		{"A plain headline", "", "", "A plain headline", ""},
+		{"A plain headline", "Some Site", "", "A plain headline", "Some Site"},
+	}
+
+	for _, test := range tests {
+		title, site := CleanTitle(test.rawTitle, test.siteName, test.host)
+		if title != test.title || site != test.site {
+			t.Errorf("CleanTitle(%q, %q, %q) = %q, %q, want %q, %q",
+				test.rawTitle, test.siteName, test.host, title, site, test.title, test.site)
+		}
+	}
+}
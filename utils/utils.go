@@ -2,6 +2,8 @@ package utils
 
 import (
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/PuerkitoBio/goquery"
 )
@@ -24,6 +26,10 @@ func ExtractTitle(doc *goquery.Document) string {
 		return title
 	}
 
+	if title := ExtractDublinCore(doc).Get("title"); len(title) > 0 {
+		return title
+	}
+
 	return ""
 }
 
@@ -41,21 +47,31 @@ func ExtractDescription(doc *goquery.Document) string {
 		return description
 	}
 
+	if description := ExtractDublinCore(doc).First("description", "abstract"); len(description) > 0 {
+		return description
+	}
+
 	return ""
 }
 
 func ExtractThumbnailURL(doc *goquery.Document) string {
 
 	if thumbnail, exists := doc.Find("meta[name='thumbnail']").First().Attr("content"); exists == true {
-		return thumbnail
+		if thumbnail = ResolveURL(doc, thumbnail); len(thumbnail) > 0 {
+			return thumbnail
+		}
 	}
 
 	if thumbnail, exists := doc.Find("meta[property='og:image']").First().Attr("content"); exists == true {
-		return thumbnail
+		if thumbnail = ResolveURL(doc, thumbnail); len(thumbnail) > 0 {
+			return thumbnail
+		}
 	}
 
 	if thumbnail, exists := doc.Find("meta[name='twitter:image']").First().Attr("content"); exists == true {
-		return thumbnail
+		if thumbnail = ResolveURL(doc, thumbnail); len(thumbnail) > 0 {
+			return thumbnail
+		}
 	}
 
 	return ""
@@ -64,18 +80,84 @@ func ExtractThumbnailURL(doc *goquery.Document) string {
 func ExtractCanonicalURL(doc *goquery.Document, response *http.Response) string {
 
 	if canonical, exists := doc.Find("link[rel='canonical']").First().Attr("href"); exists == true {
-		return canonical
+		if canonical = ResolveURL(doc, canonical); len(canonical) > 0 {
+			return canonical
+		}
 	}
 
 	if canonical, exists := doc.Find("meta[property='og:url']").First().Attr("content"); exists == true {
-		return canonical
+		if canonical = ResolveURL(doc, canonical); len(canonical) > 0 {
+			return canonical
+		}
 	}
 
 	if canonical, exists := doc.Find("meta[name='twitter:url']").First().Attr("content"); exists == true {
-		return canonical
+		if canonical = ResolveURL(doc, canonical); len(canonical) > 0 {
+			return canonical
+		}
 	}
 
 	// No canonical URL found - so return the URL (the latest redirected URL)
 	// response.Request.URL contains the final URL that was fetched after redirects
 	return response.Request.URL.String()
 }
+
+/*
+ResolveURL resolves a (possibly relative or scheme-less) URL reference found in the
+document against the document's `<base href>`, or the document URL in its absence.
+For documents created from a response, the document URL is the final URL that was
+fetched after redirects.
+
+Only http(s) URLs are returned - an empty string is returned for anything else
+(eg: `javascript:` and `data:` URLs) or if the reference can't be resolved.
+*/
+func ResolveURL(doc *goquery.Document, ref string) string {
+	return HTTPURL(resolveReference(doc, ref))
+}
+
+// HTTPURL returns the URL as is if it is an absolute http(s) URL, or an empty string otherwise.
+func HTTPURL(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil && (u.Scheme == "http" || u.Scheme == "https") && len(u.Host) > 0 {
+		return rawURL
+	}
+
+	return ""
+}
+
+// resolveReference resolves a reference against the document base URL without restricting the scheme.
+// This is meant for identifiers (eg: `urn:isbn:...`), which need not be fetchable URLs.
+func resolveReference(doc *goquery.Document, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if len(ref) == 0 {
+		// An empty reference would resolve to the document itself.
+		return ""
+	}
+
+	base := baseURL(doc)
+	if base == nil {
+		return ref
+	}
+
+	if resolved, err := base.Parse(ref); err == nil {
+		return resolved.String()
+	}
+
+	return ref
+}
+
+// baseURL returns the URL against which the relative references in the document are resolved.
+func baseURL(doc *goquery.Document) *url.URL {
+	if href, exists := doc.Find("base[href]").First().Attr("href"); exists == true {
+		href = strings.TrimSpace(href)
+
+		if doc.Url != nil {
+			if base, err := doc.Url.Parse(href); err == nil {
+				return base
+			}
+		} else if base, err := url.Parse(href); err == nil && base.IsAbs() {
+			return base
+		}
+	}
+
+	return doc.Url
+}
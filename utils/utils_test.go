@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// newTestDocument parses the HTML into a document at the URL, as the scraper does for a fetched page.
+func newTestDocument(t *testing.T, pageURL string, body string) *goquery.Document {
+	t.Helper()
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Unable to parse the document: %v", err)
+	}
+
+	if doc.Url, err = url.Parse(pageURL); err != nil {
+		t.Fatalf("Invalid URL %q: %v", pageURL, err)
+	}
+
+	return doc
+}
+
+func TestResolveURL(t *testing.T) {
+	tests := []struct {
+		base string
+		ref  string
+		want string
+	}{
+		{"", "c.png", "https://example.com/a/c.png"},
+		{"", " /c.png ", "https://example.com/c.png"},
+		{"", "//cdn.example.com/c.png", "https://cdn.example.com/c.png"},
+		{"", "http://other.com/", "http://other.com/"},
+		{"https://cdn.example.com/assets/", "c.png", "https://cdn.example.com/assets/c.png"},
+		{"/static/", "c.png", "https://example.com/static/c.png"},
+		{"", "javascript:void(0)", ""},
+		{"", "data:image/png;base64,AAAA", ""},
+		{"", "mailto:someone@example.com", ""},
+		{"", "", ""},
+		{"", "  ", ""},
+	}
+
+	for _, test := range tests {
+		head := ""
+		if len(test.base) > 0 {
+			head = `<base href="` + test.base + `">`
+		}
+		doc := newTestDocument(t, "https://example.com/a/b", "<html><head>"+head+"</head><body></body></html>")
+
+		if resolved := ResolveURL(doc, test.ref); resolved != test.want {
+			t.Errorf("ResolveURL(%q) with the base %q = %q, want %q", test.ref, test.base, resolved, test.want)
+		}
+	}
+}
+
+func TestResolveReference(t *testing.T) {
+	doc := newTestDocument(t, "https://example.com/a/b", "<html><head></head><body></body></html>")
+
+	tests := map[string]string{
+		"#id":          "https://example.com/a/b#id",
+		"urn:isbn:123": "urn:isbn:123",
+		"":             "",
+	}
+
+	for ref, want := range tests {
+		if resolved := resolveReference(doc, ref); resolved != want {
+			t.Errorf("resolveReference(%q) = %q, want %q", ref, resolved, want)
+		}
+	}
+}
+
+func TestExtractThumbnailURL(t *testing.T) {
+	tests := []struct {
+		head string
+		want string
+	}{
+		{`<meta property="og:image" content="/og.jpg"><meta name="twitter:image" content="/tw.jpg">`, "https://example.com/og.jpg"},
+		{`<meta property="og:image" content="data:image/png;base64,AAAA"><meta name="twitter:image" content="/tw.jpg">`, "https://example.com/tw.jpg"},
+		{`<meta name="thumbnail" content="javascript:alert(1)">`, ""},
+		{``, ""},
+	}
+
+	for _, test := range tests {
+		doc := newTestDocument(t, "https://example.com/page", "<html><head>"+test.head+"</head><body></body></html>")
+
+		if thumbnail := ExtractThumbnailURL(doc); thumbnail != test.want {
+			t.Errorf("ExtractThumbnailURL(%q) = %q, want %q", test.head, thumbnail, test.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// WebApp is the web app metadata of a site - its name and colors - from the manifest and the meta tags.
+type WebApp struct {
+	Name            string `json:"name,omitempty"`
+	ShortName       string `json:"shortName,omitempty"`
+	ThemeColor      string `json:"themeColor,omitempty"`
+	BackgroundColor string `json:"backgroundColor,omitempty"`
+	TileColor       string `json:"tileColor,omitempty"` // Windows tile color
+	ManifestURL     string `json:"manifestUrl,omitempty"`
+}
+
+var hexColor = regexp.MustCompile(`^#?([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+
+/*
+ExtractWebApp extracts the web app metadata from the meta tags - `theme-color`,
+`application-name`, `apple-mobile-web-app-title` and `msapplication-TileColor` - and
+the (optional) web app manifest. The meta tags take precedence over the manifest, as
+they do in browsers. Returns nil if there is none.
+*/
+func ExtractWebApp(doc *goquery.Document, manifest *Manifest) *WebApp {
+	webApp := &WebApp{
+		Name:       collapseWhitespace(metaContent(doc, "meta[name='application-name']")),
+		ShortName:  collapseWhitespace(metaContent(doc, "meta[name='apple-mobile-web-app-title']")),
+		ThemeColor: NormalizeColor(themeColor(doc)),
+		TileColor:  NormalizeColor(metaContent(doc, "meta[name='msapplication-TileColor'], meta[name='msapplication-tilecolor']")),
+	}
+
+	if manifest != nil {
+		webApp.ManifestURL = manifest.URL
+		webApp.BackgroundColor = NormalizeColor(manifest.BackgroundColor)
+
+		if len(webApp.Name) == 0 {
+			webApp.Name = collapseWhitespace(manifest.Name)
+		}
+		if len(webApp.ShortName) == 0 {
+			webApp.ShortName = collapseWhitespace(manifest.ShortName)
+		}
+		if len(webApp.ThemeColor) == 0 {
+			webApp.ThemeColor = NormalizeColor(manifest.ThemeColor)
+		}
+	}
+
+	if *webApp == (WebApp{}) {
+		return nil
+	}
+
+	return webApp
+}
+
+/*
+NormalizeColor normalizes a CSS color: hex colors are lowercased and expanded to 6
+(or 8, with alpha) digits, eg: `#FFF` => `#ffffff`. Other colors (eg: `rgb(0, 0, 0)`
+or `navy`) are lowercased as is.
+*/
+func NormalizeColor(color string) string {
+	color = strings.ToLower(strings.TrimSpace(color))
+
+	matches := hexColor.FindStringSubmatch(color)
+	if matches == nil {
+		if strings.HasPrefix(color, "#") {
+			// Not a valid hex color
+			return ""
+		}
+		return color
+	}
+
+	digits := matches[1]
+	if len(digits) <= 4 {
+		expanded := ""
+		for _, digit := range digits {
+			expanded += string(digit) + string(digit)
+		}
+		digits = expanded
+	}
+
+	return "#" + digits
+}
+
+// themeColor returns the `theme-color` for the default (light) color scheme, if there are several for the media queries.
+func themeColor(doc *goquery.Document) string {
+	color := ""
+
+	doc.Find("meta[name='theme-color'][content]").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		media := strings.ToLower(attr(s, "media"))
+		if i == 0 || len(media) == 0 || strings.Contains(media, "light") {
+			color = attr(s, "content")
+		}
+
+		return len(media) > 0 && strings.Contains(media, "light") == false
+	})
+
+	return color
+}
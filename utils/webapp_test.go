@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractWebApp(t *testing.T) {
+	manifest := &Manifest{
+		URL:             "https://example.com/manifest.json",
+		Name:            "Example  App",
+		ShortName:       "Example",
+		ThemeColor:      "#ABC",
+		BackgroundColor: "#FFFFFF",
+	}
+
+	tests := []struct {
+		name     string
+		html     string
+		manifest *Manifest
+		want     *WebApp
+	}{
+		{
+			"meta tags",
+			`<meta name="application-name" content="Meta App"><meta name="theme-color" content="#336699">
+			<meta name="msapplication-TileColor" content="#DA532C">`,
+			nil,
+			&WebApp{Name: "Meta App", ThemeColor: "#336699", TileColor: "#da532c"},
+		},
+		{
+			"meta tags take precedence over the manifest",
+			`<meta name="apple-mobile-web-app-title" content="Meta"><meta name="theme-color" content="navy">`,
+			manifest,
+			&WebApp{Name: "Example App", ShortName: "Meta", ThemeColor: "navy", BackgroundColor: "#ffffff", ManifestURL: "https://example.com/manifest.json"},
+		},
+		{
+			"manifest",
+			``,
+			manifest,
+			&WebApp{Name: "Example App", ShortName: "Example", ThemeColor: "#aabbcc", BackgroundColor: "#ffffff", ManifestURL: "https://example.com/manifest.json"},
+		},
+		{
+			"light color scheme",
+			`<meta name="theme-color" media="(prefers-color-scheme: dark)" content="#000"><meta name="theme-color" media="(prefers-color-scheme: light)" content="#fff">`,
+			nil,
+			&WebApp{ThemeColor: "#ffffff"},
+		},
+		{"none", `<meta name="theme-color" content="#zzz">`, nil, nil},
+	}
+
+	for _, test := range tests {
+		doc := newTestDocument(t, "https://example.com/", "<html><head>"+test.html+"</head></html>")
+
+		if got := ExtractWebApp(doc, test.manifest); reflect.DeepEqual(got, test.want) == false {
+			t.Errorf("%s: ExtractWebApp() = %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestNormalizeColor(t *testing.T) {
+	tests := map[string]string{
+		"#FFF":         "#ffffff",
+		"#abcd":        "#aabbccdd",
+		" #336699 ":    "#336699",
+		"336699":       "#336699",
+		"#11223344":    "#11223344",
+		"#12345":       "",
+		"RGB(0, 0, 0)": "rgb(0, 0, 0)",
+		"Navy":         "navy",
+		"":             "",
+	}
+
+	for color, want := range tests {
+		if got := NormalizeColor(color); got != want {
+			t.Errorf("NormalizeColor(%q) = %q, want %q", color, got, want)
+		}
+	}
+}